@@ -0,0 +1,285 @@
+package observability
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy decides what the AsyncLogger does when its queue is full
+type OverflowPolicy int
+
+const (
+	// BlockOnOverflow waits for the queue to have room
+	BlockOnOverflow OverflowPolicy = iota
+	// DropNewestOnOverflow drops the entry being logged
+	DropNewestOnOverflow
+	// DropOldestOnOverflow drops the oldest queued entry to make room
+	DropOldestOnOverflow
+	// DropBelowLevelOnOverflow drops the entries below the drop level and waits for the rest
+	DropBelowLevelOnOverflow
+)
+
+// defaultAsyncQueueSize is the queue size used when none is configured
+const defaultAsyncQueueSize = 1024
+
+// AsyncLogger is an ObservabilityLogger writing to another logger from a background worker
+//
+// Log calls only enqueue the log values, so they must not be built from pooled builders
+// and lazy values are evaluated by the worker.
+// Shutdown must be called to drain the queue, entries logged afterwards are dropped.
+type AsyncLogger[T any] struct {
+	logger ObservabilityLogger[T]
+	queue  chan logCall[T]
+	// policy is applied when the queue is full
+	policy OverflowPolicy
+	// dropLevel is the level below which entries are dropped by DropBelowLevelOnOverflow
+	dropLevel Level
+	// onDrop is called with the total of dropped entries after every drop
+	onDrop func(dropped uint64)
+	// queueSize is the capacity of the queue
+	queueSize int
+
+	dropped atomic.Uint64
+	// closing is closed by Shutdown so the senders waiting for room drop their entries and release the lock
+	closing   chan struct{}
+	closeOnce sync.Once
+	// mu guards closed, senders hold the read lock while enqueueing
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+// AsyncLoggerOption configures an AsyncLogger
+type AsyncLoggerOption[T any] func(*AsyncLogger[T])
+
+// WithQueueSize sets the capacity of the queue
+func WithQueueSize[T any](size int) AsyncLoggerOption[T] {
+	return func(l *AsyncLogger[T]) {
+		l.queueSize = size
+	}
+}
+
+// WithOverflowPolicy sets the policy applied when the queue is full
+func WithOverflowPolicy[T any](policy OverflowPolicy) AsyncLoggerOption[T] {
+	return func(l *AsyncLogger[T]) {
+		l.policy = policy
+	}
+}
+
+// WithDropBelowLevel drops the entries below the given level when the queue is full
+func WithDropBelowLevel[T any](level Level) AsyncLoggerOption[T] {
+	return func(l *AsyncLogger[T]) {
+		l.policy = DropBelowLevelOnOverflow
+		l.dropLevel = level
+	}
+}
+
+// WithDropHandler sets a function called with the total of dropped entries after every drop
+func WithDropHandler[T any](onDrop func(dropped uint64)) AsyncLoggerOption[T] {
+	return func(l *AsyncLogger[T]) {
+		l.onDrop = onDrop
+	}
+}
+
+// NewAsyncLogger creates a new AsyncLogger writing to the given logger and starts its worker
+func NewAsyncLogger[T any](logger ObservabilityLogger[T], opts ...AsyncLoggerOption[T]) *AsyncLogger[T] {
+	l := &AsyncLogger[T]{
+		logger:    logger,
+		queueSize: defaultAsyncQueueSize,
+		closing:   make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.queueSize < 1 {
+		l.queueSize = 1
+	}
+	l.queue = make(chan logCall[T], l.queueSize)
+
+	go l.run()
+	return l
+}
+
+// Enabled checks if the wrapped logger emits logs at the given level
+func (l *AsyncLogger[T]) Enabled(level Level) bool {
+	return l.logger.Enabled(level)
+}
+
+// LogInfo enqueues an info message with the given values
+func (l *AsyncLogger[T]) LogInfo(lv LogValues[T]) {
+	l.enqueue(logCall[T]{level: InfoLevel, lv: lv})
+}
+
+// LogError enqueues an error message with the given values
+func (l *AsyncLogger[T]) LogError(lv LogValues[T]) {
+	l.enqueue(logCall[T]{level: ErrorLevel, lv: lv})
+}
+
+// LogDebug enqueues a debug message with the given values
+func (l *AsyncLogger[T]) LogDebug(lv LogValues[T]) {
+	l.enqueue(logCall[T]{level: DebugLevel, lv: lv})
+}
+
+// LogInfoContext enqueues an info message with the given values and observability context
+func (l *AsyncLogger[T]) LogInfoContext(ctx context.Context, lv LogValues[T]) {
+	l.enqueue(logCall[T]{level: InfoLevel, ctx: ctx, lv: lv})
+}
+
+// LogErrorContext enqueues an error message with the given values and observability context
+func (l *AsyncLogger[T]) LogErrorContext(ctx context.Context, lv LogValues[T]) {
+	l.enqueue(logCall[T]{level: ErrorLevel, ctx: ctx, lv: lv})
+}
+
+// LogDebugContext enqueues a debug message with the given values and observability context
+func (l *AsyncLogger[T]) LogDebugContext(ctx context.Context, lv LogValues[T]) {
+	l.enqueue(logCall[T]{level: DebugLevel, ctx: ctx, lv: lv})
+}
+
+// Log enqueues a message at the given level, refer to LevelLogger
+func (l *AsyncLogger[T]) Log(ctx context.Context, level Level, lv LogValues[T]) {
+	l.enqueue(logCall[T]{level: level, ctx: ctx, lv: lv})
+}
+
+// RequiresSpanContext reports whether the wrapped logger requires the context of every call, refer to SpanContextLogger
+func (l *AsyncLogger[T]) RequiresSpanContext() bool {
+	return requiresSpanContext(l.logger)
+}
+
+// SpanStarted enqueues the start of the span for the wrapped logger if it's a SpanStartObserver,
+// so it's notified before the logs written in the span
+// The notification is queued at the error level and can only be dropped by the DropNewest and DropOldest policies
+func (l *AsyncLogger[T]) SpanStarted(tv TraceValues) {
+	if _, ok := l.logger.(SpanStartObserver); !ok {
+		return
+	}
+	l.enqueue(logCall[T]{level: ErrorLevel, spanStarted: &tv})
+}
+
+// SpanEnded enqueues the end of the span for the wrapped logger if it's a SpanEndObserver,
+// so it's notified after the logs written before the end
+// The notification is queued at the error level and can only be dropped by the DropNewest and DropOldest policies
+func (l *AsyncLogger[T]) SpanEnded(tv TraceValues) {
+	if _, ok := l.logger.(SpanEndObserver); !ok {
+		return
+	}
+	l.enqueue(logCall[T]{level: ErrorLevel, spanEnded: &tv})
+}
+
+// Dropped returns the number of entries dropped so far
+func (l *AsyncLogger[T]) Dropped() uint64 {
+	return l.dropped.Load()
+}
+
+// Shutdown stops accepting entries and waits for the queued ones to be written
+// The entries waiting for room in the queue are dropped
+// Returns the context error if it's done before the queue is drained
+func (l *AsyncLogger[T]) Shutdown(ctx context.Context) error {
+	l.closeOnce.Do(func() { close(l.closing) })
+
+	closed := make(chan struct{})
+	go func() {
+		l.mu.Lock()
+		if !l.closed {
+			l.closed = true
+			close(l.queue)
+		}
+		l.mu.Unlock()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run writes the queued entries until the queue is closed
+func (l *AsyncLogger[T]) run() {
+	defer close(l.done)
+	for call := range l.queue {
+		if call.spanStarted != nil {
+			notifySpanStarted(l.logger, *call.spanStarted)
+			continue
+		}
+		if call.spanEnded != nil {
+			notifySpanEnded(l.logger, *call.spanEnded)
+			continue
+		}
+		call.dispatch(l.logger)
+	}
+}
+
+// enqueue adds the call to the queue applying the overflow policy
+func (l *AsyncLogger[T]) enqueue(call logCall[T]) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		l.drop()
+		return
+	}
+
+	switch l.policy {
+	case DropNewestOnOverflow:
+		l.trySend(call)
+	case DropOldestOnOverflow:
+		for !l.trySendQuiet(call) {
+			select {
+			case <-l.queue:
+				l.drop()
+			default:
+			}
+		}
+	case DropBelowLevelOnOverflow:
+		if call.level < l.dropLevel {
+			l.trySend(call)
+			return
+		}
+		l.send(call)
+	default:
+		l.send(call)
+	}
+}
+
+// send adds the call to the queue waiting for room, or drops it once Shutdown is called
+func (l *AsyncLogger[T]) send(call logCall[T]) {
+	select {
+	case l.queue <- call:
+	case <-l.closing:
+		l.drop()
+	}
+}
+
+// trySend adds the call to the queue or drops it if the queue is full
+func (l *AsyncLogger[T]) trySend(call logCall[T]) {
+	if !l.trySendQuiet(call) {
+		l.drop()
+	}
+}
+
+// trySendQuiet adds the call to the queue if it isn't full
+func (l *AsyncLogger[T]) trySendQuiet(call logCall[T]) bool {
+	select {
+	case l.queue <- call:
+		return true
+	default:
+		return false
+	}
+}
+
+// drop counts a dropped entry and reports it
+func (l *AsyncLogger[T]) drop() {
+	dropped := l.dropped.Add(1)
+	if l.onDrop != nil {
+		l.onDrop(dropped)
+	}
+}
@@ -0,0 +1,155 @@
+package observability
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newGatedLogger creates a recordingLogger blocking every call until the gate is closed
+func newGatedLogger() *recordingLogger {
+	return &recordingLogger{
+		level:   DebugLevel,
+		entered: make(chan struct{}, 16),
+		gate:    make(chan struct{}),
+	}
+}
+
+// Test that the AsyncLogger writes every entry in order before Shutdown returns
+func TestAsyncLogger_Shutdown(t *testing.T) {
+	logger := &recordingLogger{level: DebugLevel}
+	async := NewAsyncLogger[string](logger)
+
+	ctx := context.Background()
+	async.LogInfo(msg("info"))
+	async.LogDebugContext(ctx, msg("debug"))
+	async.LogErrorContext(ctx, msg("error"))
+
+	assert.NoError(t, async.Shutdown(ctx))
+	assert.Equal(t, []string{"info", "debug", "error"}, logger.messages())
+
+	entries := logger.all()
+	assert.Nil(t, entries[0].ctx)
+	assert.Equal(t, ctx, entries[1].ctx)
+	assert.Equal(t, ErrorLevel, entries[2].level)
+
+	// Assert entries logged after Shutdown are dropped
+	async.LogInfo(msg("late"))
+	assert.Equal(t, uint64(1), async.Dropped())
+	assert.Len(t, logger.all(), 3)
+}
+
+// Test that each overflow policy drops the expected entries when the queue is full
+func TestAsyncLogger_OverflowPolicies(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  AsyncLoggerOption[string]
+		log  func(l *AsyncLogger[string])
+		want []string
+	}{
+		{
+			name: "drop newest",
+			opt:  WithOverflowPolicy[string](DropNewestOnOverflow),
+			log: func(l *AsyncLogger[string]) {
+				l.LogInfo(msg("queued"))
+				l.LogInfo(msg("dropped"))
+			},
+			want: []string{"first", "queued"},
+		},
+		{
+			name: "drop oldest",
+			opt:  WithOverflowPolicy[string](DropOldestOnOverflow),
+			log: func(l *AsyncLogger[string]) {
+				l.LogInfo(msg("dropped"))
+				l.LogInfo(msg("queued"))
+			},
+			want: []string{"first", "queued"},
+		},
+		{
+			name: "drop below level",
+			opt:  WithDropBelowLevel[string](InfoLevel),
+			log: func(l *AsyncLogger[string]) {
+				l.LogDebug(msg("queued"))
+				l.LogDebug(msg("dropped"))
+			},
+			want: []string{"first", "queued"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := newGatedLogger()
+			var reported uint64
+			async := NewAsyncLogger[string](logger, WithQueueSize[string](1), tt.opt,
+				WithDropHandler[string](func(dropped uint64) { reported = dropped }))
+
+			// Wait for the worker to hold the first entry so the queue is empty
+			async.LogInfo(msg("first"))
+			<-logger.entered
+			tt.log(async)
+
+			close(logger.gate)
+			assert.NoError(t, async.Shutdown(context.Background()))
+			assert.Equal(t, tt.want, logger.messages())
+			assert.Equal(t, uint64(1), async.Dropped())
+			assert.Equal(t, uint64(1), reported)
+		})
+	}
+}
+
+// Test that Shutdown returns the context error when the queue can't be drained in time
+func TestAsyncLogger_ShutdownTimeout(t *testing.T) {
+	logger := newGatedLogger()
+	async := NewAsyncLogger[string](logger)
+	async.LogInfo(msg("blocked"))
+	<-logger.entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, async.Shutdown(ctx), context.DeadlineExceeded)
+
+	close(logger.gate)
+	assert.NoError(t, async.Shutdown(context.Background()))
+	assert.Equal(t, []string{"blocked"}, logger.messages())
+}
+
+// Test that Shutdown returns at its deadline while entries wait for room in a full queue, and drops them
+func TestAsyncLogger_ShutdownFullQueue(t *testing.T) {
+	for _, opt := range []AsyncLoggerOption[string]{
+		WithOverflowPolicy[string](BlockOnOverflow),
+		WithDropBelowLevel[string](InfoLevel),
+	} {
+		logger := newGatedLogger()
+		async := NewAsyncLogger[string](logger, WithQueueSize[string](1), opt)
+		async.LogError(msg("blocked"))
+		<-logger.entered
+		async.LogError(msg("queued"))
+
+		waiting := make(chan struct{})
+		go func() {
+			defer close(waiting)
+			async.LogError(msg("waiting"))
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		assert.ErrorIs(t, async.Shutdown(ctx), context.DeadlineExceeded)
+		cancel()
+		<-waiting
+
+		close(logger.gate)
+		assert.NoError(t, async.Shutdown(context.Background()))
+		assert.Equal(t, []string{"blocked", "queued"}, logger.messages())
+		assert.Equal(t, uint64(1), async.Dropped())
+	}
+}
+
+// Test that Enabled is delegated to the wrapped logger
+func TestAsyncLogger_Enabled(t *testing.T) {
+	async := NewAsyncLogger[string](&recordingLogger{level: InfoLevel})
+	defer async.Shutdown(context.Background())
+
+	assert.False(t, async.Enabled(DebugLevel))
+	assert.True(t, async.Enabled(InfoLevel))
+}
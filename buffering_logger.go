@@ -0,0 +1,285 @@
+package observability
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// defaultMaxBufferedEntries is the number of entries buffered per trace when none is configured
+	defaultMaxBufferedEntries = 256
+	// defaultMaxBufferedTraces is the number of traces buffered when none is configured
+	defaultMaxBufferedTraces = 1024
+)
+
+// SpanEndObserver is implemented by loggers notified when the spans started by the handler end
+// Wrapping loggers such as MultiLogger and AsyncLogger forward the notification to their loggers
+type SpanEndObserver interface {
+	// SpanEnded is called with the trace values of the span once it ended
+	SpanEnded(tv TraceValues)
+}
+
+// SpanStartObserver is implemented by loggers notified when the handler starts a span
+// Wrapping loggers such as MultiLogger and AsyncLogger forward the notification to their loggers
+type SpanStartObserver interface {
+	// SpanStarted is called with the trace values of the span once started
+	SpanStarted(tv TraceValues)
+}
+
+// SpanContextLogger is implemented by loggers relating every log to the span of the handler
+// The handler calls their Log*Context methods with its context, even for its calls made without context
+// Wrapping loggers such as MultiLogger and AsyncLogger require it when one of their loggers does
+type SpanContextLogger interface {
+	// RequiresSpanContext reports whether the logger requires the context of every call
+	RequiresSpanContext() bool
+}
+
+// requiresSpanContext reports whether the logger requires the context of every call, refer to SpanContextLogger
+func requiresSpanContext(logger any) bool {
+	l, ok := logger.(SpanContextLogger)
+	return ok && l.RequiresSpanContext()
+}
+
+// notifySpanStarted notifies the logger that the span started if it's a SpanStartObserver
+func notifySpanStarted(logger any, tv TraceValues) {
+	if observer, ok := logger.(SpanStartObserver); ok {
+		observer.SpanStarted(tv)
+	}
+}
+
+// notifySpanEnded notifies the logger that the span ended if it's a SpanEndObserver
+func notifySpanEnded(logger any, tv TraceValues) {
+	if observer, ok := logger.(SpanEndObserver); ok {
+		observer.SpanEnded(tv)
+	}
+}
+
+// BufferingLogger is an ObservabilityLogger holding the logs below the flush level per trace
+// and writing them to another logger only once a log at the flush level is written in the same trace
+//
+// The buffered logs of a trace are discarded when the first span the handler started in the trace ends,
+// or its local root span when the handler started none. The handler notifies the logger through
+// SpanStartObserver and SpanEndObserver. The handler gives its context to every log as the logger is a SpanContextLogger,
+// logs without span and the Log* calls made on the logger directly without context are written immediately.
+// Buffered log values must not be built from pooled builders and lazy values are evaluated when flushed.
+type BufferingLogger[T any] struct {
+	logger ObservabilityLogger[T]
+	// flushLevel is the level from which the buffered logs of the trace are written
+	flushLevel Level
+	// maxEntries is the number of entries buffered per trace, the oldest ones are dropped beyond it
+	maxEntries int
+	// maxTraces is the number of traces buffered, the oldest trace is dropped beyond it
+	maxTraces int
+
+	mu     sync.Mutex
+	traces map[trace.TraceID]*traceBuffer[T]
+	// order contains the buffered traces from the oldest to the newest
+	order   []trace.TraceID
+	dropped atomic.Uint64
+}
+
+// traceBuffer contains the logs buffered for a trace
+type traceBuffer[T any] struct {
+	calls []logCall[T]
+	// rootSpanId is the id of the first span started by the handler in the trace, its end discards the buffer
+	rootSpanId string
+	// flushed is set once the trace logged at the flush level, its logs are written immediately afterwards
+	flushed bool
+}
+
+// BufferingLoggerOption configures a BufferingLogger
+type BufferingLoggerOption[T any] func(*BufferingLogger[T])
+
+// WithFlushLevel sets the level from which the buffered logs of the trace are written, ErrorLevel by default
+func WithFlushLevel[T any](level Level) BufferingLoggerOption[T] {
+	return func(l *BufferingLogger[T]) {
+		l.flushLevel = level
+	}
+}
+
+// WithMaxBufferedEntries sets the number of entries buffered per trace, the oldest ones are dropped beyond it
+// Zero removes the cap
+func WithMaxBufferedEntries[T any](entries int) BufferingLoggerOption[T] {
+	return func(l *BufferingLogger[T]) {
+		l.maxEntries = entries
+	}
+}
+
+// WithMaxBufferedTraces sets the number of traces buffered, the logs of the oldest trace are dropped beyond it
+// Zero removes the cap
+func WithMaxBufferedTraces[T any](traces int) BufferingLoggerOption[T] {
+	return func(l *BufferingLogger[T]) {
+		l.maxTraces = traces
+	}
+}
+
+// NewBufferingLogger creates a BufferingLogger writing to the given logger
+func NewBufferingLogger[T any](logger ObservabilityLogger[T], opts ...BufferingLoggerOption[T]) *BufferingLogger[T] {
+	l := &BufferingLogger[T]{
+		logger:     logger,
+		flushLevel: ErrorLevel,
+		maxEntries: defaultMaxBufferedEntries,
+		maxTraces:  defaultMaxBufferedTraces,
+		traces:     make(map[trace.TraceID]*traceBuffer[T]),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Enabled checks if the logger writes logs at the given level
+func (l *BufferingLogger[T]) Enabled(level Level) bool {
+	return l.logger.Enabled(level)
+}
+
+// RequiresSpanContext returns true, the logs are buffered per trace
+func (l *BufferingLogger[T]) RequiresSpanContext() bool {
+	return true
+}
+
+// Dropped returns the number of buffered entries dropped by the memory caps so far
+func (l *BufferingLogger[T]) Dropped() uint64 {
+	return l.dropped.Load()
+}
+
+// SpanStarted keeps the id of the first span started in the trace, the buffer is discarded once it ends
+func (l *BufferingLogger[T]) SpanStarted(tv TraceValues) {
+	traceID, err := trace.TraceIDFromHex(tv.TraceId)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if buffer := l.buffer(traceID); buffer.rootSpanId == "" {
+		buffer.rootSpanId = tv.SpanId
+	}
+}
+
+// SpanEnded discards the buffered logs of the trace when the first span started in the trace ended,
+// or the local root span for the traces without started span
+func (l *BufferingLogger[T]) SpanEnded(tv TraceValues) {
+	traceID, err := trace.TraceIDFromHex(tv.TraceId)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	buffer, ok := l.traces[traceID]
+	if !ok {
+		return
+	}
+	localRoot := tv.ParentSpanId == "" || tv.Remote
+	if buffer.rootSpanId == tv.SpanId || (buffer.rootSpanId == "" && localRoot) {
+		l.remove(traceID)
+	}
+}
+
+// LogInfo writes an info message immediately, it can't be related to a trace
+func (l *BufferingLogger[T]) LogInfo(lv LogValues[T]) {
+	l.logger.LogInfo(lv)
+}
+
+// LogError writes an error message immediately, it can't be related to a trace
+func (l *BufferingLogger[T]) LogError(lv LogValues[T]) {
+	l.logger.LogError(lv)
+}
+
+// LogDebug writes a debug message immediately, it can't be related to a trace
+func (l *BufferingLogger[T]) LogDebug(lv LogValues[T]) {
+	l.logger.LogDebug(lv)
+}
+
+// LogInfoContext buffers an info message in the trace of the context
+func (l *BufferingLogger[T]) LogInfoContext(ctx context.Context, lv LogValues[T]) {
+	l.log(logCall[T]{level: InfoLevel, ctx: ctx, lv: lv})
+}
+
+// LogErrorContext buffers an error message in the trace of the context
+func (l *BufferingLogger[T]) LogErrorContext(ctx context.Context, lv LogValues[T]) {
+	l.log(logCall[T]{level: ErrorLevel, ctx: ctx, lv: lv})
+}
+
+// LogDebugContext buffers a debug message in the trace of the context
+func (l *BufferingLogger[T]) LogDebugContext(ctx context.Context, lv LogValues[T]) {
+	l.log(logCall[T]{level: DebugLevel, ctx: ctx, lv: lv})
+}
+
+// Log buffers a message at the given level in the trace of the context, refer to LevelLogger
+func (l *BufferingLogger[T]) Log(ctx context.Context, level Level, lv LogValues[T]) {
+	l.log(logCall[T]{level: level, ctx: ctx, lv: lv})
+}
+
+// log buffers the call below the flush level, or writes it after the buffered calls of its trace
+func (l *BufferingLogger[T]) log(call logCall[T]) {
+	sc := trace.SpanContextFromContext(call.ctx)
+	if !sc.IsValid() {
+		call.dispatch(l.logger)
+		return
+	}
+
+	l.mu.Lock()
+	buffer := l.buffer(sc.TraceID())
+	if buffer.flushed {
+		l.mu.Unlock()
+		call.dispatch(l.logger)
+		return
+	}
+
+	if call.level < l.flushLevel {
+		if l.maxEntries > 0 && len(buffer.calls) >= l.maxEntries {
+			buffer.calls = append(buffer.calls[:0], buffer.calls[1:]...)
+			l.dropped.Add(1)
+		}
+		buffer.calls = append(buffer.calls, call)
+		l.mu.Unlock()
+		return
+	}
+
+	calls := buffer.calls
+	buffer.calls, buffer.flushed = nil, true
+	l.mu.Unlock()
+
+	for _, buffered := range calls {
+		buffered.dispatch(l.logger)
+	}
+	call.dispatch(l.logger)
+}
+
+// buffer returns the buffer of the trace, creating it and dropping the oldest trace beyond the cap
+// Requires the lock to be held
+func (l *BufferingLogger[T]) buffer(traceID trace.TraceID) *traceBuffer[T] {
+	if buffer, ok := l.traces[traceID]; ok {
+		return buffer
+	}
+
+	if l.maxTraces > 0 && len(l.order) >= l.maxTraces {
+		oldest := l.order[0]
+		l.dropped.Add(uint64(len(l.traces[oldest].calls)))
+		l.remove(oldest)
+	}
+	buffer := &traceBuffer[T]{}
+	l.traces[traceID] = buffer
+	l.order = append(l.order, traceID)
+	return buffer
+}
+
+// remove discards the buffer of the trace
+// Requires the lock to be held
+func (l *BufferingLogger[T]) remove(traceID trace.TraceID) {
+	if _, ok := l.traces[traceID]; !ok {
+		return
+	}
+	delete(l.traces, traceID)
+	for i, id := range l.order {
+		if id == traceID {
+			l.order = append(l.order[:i], l.order[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that the buffered logs are written before the error of their trace
+func TestBufferingLogger_FlushOnError(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	h := NewObservabilityHandler[string](ctx, "checkout", NewBufferingLogger[string](logger))
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	h.LogDebugContext(msg("loading cart"))
+	h.LogInfoContext(msg("charging card"))
+	assert.Empty(t, logger.all())
+
+	h.LogErrorContext(msg("card declined"))
+	h.LogDebugContext(msg("rolling back"))
+	// Assert the trace logs are written immediately once flushed
+	assert.Equal(t, []string{"loading cart", "charging card", "card declined", "rolling back"}, logger.messages())
+	assert.Equal(t, DebugLevel, logger.all()[0].level)
+}
+
+// Test that the buffered logs are discarded once the local root span ends
+func TestBufferingLogger_DiscardOnSpanEnd(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	buffering := NewBufferingLogger[string](logger)
+	h := NewObservabilityHandler[string](ctx, "checkout", buffering)
+
+	_, end := h.StartSpan("POST /orders")
+	h.LogDebugContext(msg("loading cart"))
+	_, endChild := h.StartSpan("load cart")
+	endChild()
+	// Assert the logs are kept when a child span ends
+	assert.Len(t, buffering.traces, 1)
+	end()
+	assert.Empty(t, buffering.traces)
+
+	// Assert logs without span are written immediately
+	NewObservabilityHandler[string](context.Background(), "checkout", buffering).LogInfo(msg("no span"))
+	assert.Equal(t, []string{"no span"}, logger.messages())
+}
+
+// Test that the buffered logs are discarded once the first span of the handler ends under a local parent
+func TestBufferingLogger_DiscardOnSpanEndWithLocalParent(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	buffering := NewBufferingLogger[string](logger)
+	// The span of a middleware started outside the handler
+	ctx, middleware := tp.Tracer("otelhttp").Start(ctx, "HTTP GET")
+	defer middleware.End()
+
+	first := NewObservabilityHandler[string](ctx, "checkout", buffering)
+	_, end := first.StartSpan("GET /orders")
+	first.LogDebugContext(msg("debug of a successful request"))
+	end()
+	assert.Empty(t, buffering.traces)
+
+	second := NewObservabilityHandler[string](ctx, "checkout", buffering)
+	_, end = second.StartSpan("POST /orders")
+	defer end()
+	second.LogErrorContext(msg("error"))
+	assert.Equal(t, []string{"error"}, logger.messages())
+}
+
+// Test that the handler calls made without context are buffered in the trace of its span
+func TestBufferingLogger_HandlerCallsWithoutContext(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	h := NewObservabilityHandler[string](ctx, "checkout", NewBufferingLogger[string](logger))
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	h.LogDebug(msg("loading cart"))
+	h.LogInfo(msg("charging card"))
+	assert.Empty(t, logger.all())
+
+	h.LogError(msg("card declined"))
+	assert.Equal(t, []string{"loading cart", "charging card", "card declined"}, logger.messages())
+}
+
+// Test that the wrapping loggers forward the span context and the end of the spans
+func TestBufferingLogger_Wrapped(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	buffering := NewBufferingLogger[string](logger)
+	async := NewAsyncLogger[string](NewHookedLogger[string](buffering))
+	multi := NewMultiLogger[string](NewLoggerDestination[string](async, DebugLevel))
+	h := NewObservabilityHandler[string](ctx, "checkout", multi)
+
+	_, end := h.StartSpan("POST /orders")
+	h.LogDebug(msg("loading cart"))
+	end()
+	assert.NoError(t, async.Shutdown(context.Background()))
+
+	assert.Empty(t, logger.all())
+	assert.Empty(t, buffering.traces)
+}
+
+// Test that the memory caps drop the oldest entries and traces
+func TestBufferingLogger_Caps(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	buffering := NewBufferingLogger[string](logger,
+		WithMaxBufferedEntries[string](2),
+		WithMaxBufferedTraces[string](1),
+		WithFlushLevel[string](InfoLevel),
+	)
+
+	first := NewObservabilityHandler[string](ctx, "checkout", buffering)
+	first.StartSpan("first")
+	for _, text := range []string{"a", "b", "c"} {
+		first.LogDebugContext(msg(text))
+	}
+
+	second := NewObservabilityHandler[string](ctx, "checkout", buffering)
+	second.StartSpan("second")
+	second.LogDebugContext(msg("d"))
+	assert.Equal(t, uint64(3), buffering.Dropped())
+
+	// Assert the flush level can be lowered
+	second.LogInfoContext(msg("info"))
+	first.LogInfoContext(msg("first info"))
+	assert.Equal(t, []string{"d", "info", "first info"}, logger.messages())
+}
@@ -0,0 +1,284 @@
+// Package config loads the declarative configuration of the adapters and creates its tracer provider
+//
+// It lives in its own module so the core package only depends on the OpenTelemetry API.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sosalejandro/observability"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the declarative configuration used by the adapters to build an ObservabilityHandler
+//
+// It can be loaded from a YAML or JSON file and overridden by environment variables, refer to Load.
+type Config struct {
+	// ServiceName is the name of the service, used as tracer name and service.name resource
+	ServiceName string `yaml:"serviceName" json:"serviceName"`
+	// Log configures the logger backend
+	Log LogConfig `yaml:"log" json:"log"`
+	// Tracing configures the tracer provider
+	Tracing TracingConfig `yaml:"tracing" json:"tracing"`
+	// Redaction configures the values masked before being logged
+	Redaction RedactionConfig `yaml:"redaction" json:"redaction"`
+}
+
+// LogConfig configures the logger backend
+type LogConfig struct {
+	// Backend is the name of the logger backend, e.g. zap
+	Backend string `yaml:"backend" json:"backend"`
+	// Level is the minimum level name, refer to ParseLevel
+	Level string `yaml:"level" json:"level"`
+	// Format is the output format, json or console
+	Format string `yaml:"format" json:"format"`
+	// Output is stdout, stderr or a file path
+	Output string `yaml:"output" json:"output"`
+}
+
+// TracingConfig configures the tracer provider
+type TracingConfig struct {
+	// Enabled creates a tracer provider, spans are non-recording otherwise
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Exporter is the span exporter, none or stdout, other exporters are given to NewTracerProvider
+	Exporter string `yaml:"exporter" json:"exporter"`
+	// Sampler is the sampler name:
+	// always_on, always_off, traceidratio, parentbased_always_on, parentbased_always_off or parentbased_traceidratio
+	Sampler string `yaml:"sampler" json:"sampler"`
+	// SamplerArg is the ratio used by the traceidratio samplers
+	SamplerArg float64 `yaml:"samplerArg" json:"samplerArg"`
+}
+
+// RedactionConfig configures the values masked before being logged
+type RedactionConfig struct {
+	// Keys are the keys of the values to mask
+	Keys []string `yaml:"keys" json:"keys"`
+	// Mask replaces the masked values
+	Mask string `yaml:"mask" json:"mask"`
+}
+
+// Error is a configuration error pointing to the offending key
+type Error struct {
+	// Key is the path of the key, e.g. log.level
+	Key string
+	// Source is the environment variable the value was read from, empty for files
+	Source string
+	Err    error
+}
+
+// Error returns the key, the source if any and the error
+func (e *Error) Error() string {
+	if e.Source != "" {
+		return fmt.Sprintf("%s (%s): %v", e.Key, e.Source, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Default returns the configuration used as base by Load
+func Default() Config {
+	return Config{
+		Log: LogConfig{
+			Backend: "zap",
+			Level:   observability.InfoLevel.String(),
+			Format:  "json",
+			Output:  "stdout",
+		},
+		Tracing: TracingConfig{
+			Exporter:   "none",
+			Sampler:    "parentbased_always_on",
+			SamplerArg: 1,
+		},
+		Redaction: RedactionConfig{
+			Mask: "[REDACTED]",
+		},
+	}
+}
+
+// Load loads the configuration from the given file over the defaults
+// and applies the environment variables with the given prefix, refer to ApplyEnv
+// The file is skipped when path is empty, the format is chosen by its extension
+// Returns the validated configuration
+func Load(path, envPrefix string) (Config, error) {
+	cfg := Default()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, err
+		}
+		if err := cfg.decode(data, strings.TrimPrefix(filepath.Ext(path), ".")); err != nil {
+			return cfg, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if err := cfg.ApplyEnv(envPrefix); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// Parse parses the configuration in the given format (yaml or json) over the defaults
+// Returns the validated configuration
+func Parse(data []byte, format string) (Config, error) {
+	cfg := Default()
+	if err := cfg.decode(data, format); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// decode decodes the data in the given format over the configuration rejecting unknown keys
+func (c *Config) decode(data []byte, format string) error {
+	var err error
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(c)
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(c)
+	default:
+		return fmt.Errorf("unsupported config format %q", format)
+	}
+
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+// configEnv maps the environment variables suffixes to the configuration keys
+var configEnv = []struct {
+	suffix string
+	key    string
+	apply  func(*Config, string) error
+}{
+	{"SERVICE_NAME", "serviceName", func(c *Config, v string) error { c.ServiceName = v; return nil }},
+	{"LOG_BACKEND", "log.backend", func(c *Config, v string) error { c.Log.Backend = v; return nil }},
+	{"LOG_LEVEL", "log.level", func(c *Config, v string) error { c.Log.Level = v; return nil }},
+	{"LOG_FORMAT", "log.format", func(c *Config, v string) error { c.Log.Format = v; return nil }},
+	{"LOG_OUTPUT", "log.output", func(c *Config, v string) error { c.Log.Output = v; return nil }},
+	{"TRACING_ENABLED", "tracing.enabled", func(c *Config, v string) (err error) {
+		c.Tracing.Enabled, err = strconv.ParseBool(v)
+		return err
+	}},
+	{"TRACING_EXPORTER", "tracing.exporter", func(c *Config, v string) error { c.Tracing.Exporter = v; return nil }},
+	{"TRACING_SAMPLER", "tracing.sampler", func(c *Config, v string) error { c.Tracing.Sampler = v; return nil }},
+	{"TRACING_SAMPLER_ARG", "tracing.samplerArg", func(c *Config, v string) (err error) {
+		c.Tracing.SamplerArg, err = strconv.ParseFloat(v, 64)
+		return err
+	}},
+	{"REDACTION_KEYS", "redaction.keys", func(c *Config, v string) error {
+		c.Redaction.Keys = nil
+		for _, key := range strings.Split(v, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				c.Redaction.Keys = append(c.Redaction.Keys, key)
+			}
+		}
+		return nil
+	}},
+	{"REDACTION_MASK", "redaction.mask", func(c *Config, v string) error { c.Redaction.Mask = v; return nil }},
+}
+
+// ApplyEnv overrides the configuration with the environment variables named
+// after the given prefix and the key, e.g. OBSERVABILITY_LOG_LEVEL for log.level
+// redaction.keys is read as a comma separated list
+func (c *Config) ApplyEnv(prefix string) error {
+	var errs []error
+	for _, env := range configEnv {
+		name := env.suffix
+		if prefix != "" {
+			name = prefix + "_" + env.suffix
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := env.apply(c, value); err != nil {
+			errs = append(errs, &Error{Key: env.key, Source: name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks every key and returns the errors pointing to the offending keys
+func (c Config) Validate() error {
+	var errs []error
+	invalid := func(key string, err error) {
+		errs = append(errs, &Error{Key: key, Err: err})
+	}
+
+	if c.ServiceName == "" {
+		invalid("serviceName", errors.New("is required"))
+	}
+
+	if c.Log.Backend == "" {
+		invalid("log.backend", errors.New("is required"))
+	}
+	if _, err := c.Log.ParseLevel(); err != nil {
+		invalid("log.level", err)
+	}
+	if !oneOf(c.Log.Format, "json", "console") {
+		invalid("log.format", fmt.Errorf("unknown format %q, expected json or console", c.Log.Format))
+	}
+	if c.Log.Output == "" {
+		invalid("log.output", errors.New("is required"))
+	}
+
+	if !oneOf(c.Tracing.Exporter, "none", "stdout") {
+		invalid("tracing.exporter", fmt.Errorf("unknown exporter %q, expected none or stdout", c.Tracing.Exporter))
+	}
+	if !oneOf(c.Tracing.Sampler, samplerNames...) {
+		invalid("tracing.sampler", fmt.Errorf("unknown sampler %q, expected one of %s", c.Tracing.Sampler, strings.Join(samplerNames, ", ")))
+	}
+	if c.Tracing.SamplerArg < 0 || c.Tracing.SamplerArg > 1 {
+		invalid("tracing.samplerArg", fmt.Errorf("ratio %v must be between 0 and 1", c.Tracing.SamplerArg))
+	}
+
+	for i, key := range c.Redaction.Keys {
+		if key == "" {
+			invalid(fmt.Sprintf("redaction.keys[%d]", i), errors.New("must not be empty"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseLevel parses the configured level
+func (c LogConfig) ParseLevel() (observability.Level, error) {
+	return observability.ParseLevel(c.Level)
+}
+
+// RedactedKeys returns the keys to mask as a set
+func (c RedactionConfig) RedactedKeys() map[string]struct{} {
+	keys := make(map[string]struct{}, len(c.Keys))
+	for _, key := range c.Keys {
+		keys[key] = struct{}{}
+	}
+	return keys
+}
+
+// oneOf checks if the value is one of the given values
+func oneOf(value string, values ...string) bool {
+	for _, v := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sosalejandro/observability"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const yamlConfig = `
+serviceName: checkout
+log:
+  level: debug
+  format: console
+tracing:
+  enabled: true
+  sampler: traceidratio
+  samplerArg: 0.25
+redaction:
+  keys: [password, token]
+`
+
+// configErrorKeys returns the keys of the Errors joined in err
+func configErrorKeys(err error) []string {
+	var keys []string
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return keys
+	}
+	for _, err := range joined.Unwrap() {
+		var configErr *Error
+		if errors.As(err, &configErr) {
+			keys = append(keys, configErr.Key)
+		}
+	}
+	return keys
+}
+
+// Test that Parse decodes YAML over the defaults
+func TestParse_YAML(t *testing.T) {
+	cfg, err := Parse([]byte(yamlConfig), "yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "checkout", cfg.ServiceName)
+	assert.Equal(t, "zap", cfg.Log.Backend)
+	assert.Equal(t, "console", cfg.Log.Format)
+	assert.Equal(t, "stdout", cfg.Log.Output)
+	assert.Equal(t, 0.25, cfg.Tracing.SamplerArg)
+	assert.Equal(t, []string{"password", "token"}, cfg.Redaction.Keys)
+	assert.Equal(t, "[REDACTED]", cfg.Redaction.Mask)
+
+	level, err := cfg.Log.ParseLevel()
+	assert.NoError(t, err)
+	assert.Equal(t, observability.DebugLevel, level)
+}
+
+// Test that Parse decodes JSON and rejects unknown keys
+func TestParse_JSON(t *testing.T) {
+	cfg, err := Parse([]byte(`{"serviceName": "checkout", "log": {"output": "stderr"}}`), "json")
+	assert.NoError(t, err)
+	assert.Equal(t, "stderr", cfg.Log.Output)
+
+	_, err = Parse([]byte(`{"serviceName": "checkout", "log": {"lvl": "debug"}}`), "json")
+	assert.ErrorContains(t, err, `unknown field "lvl"`)
+
+	_, err = Parse([]byte("serviceName: checkout\nlog:\n  lvl: debug\n"), "yaml")
+	assert.ErrorContains(t, err, "field lvl not found")
+}
+
+// Test that Validate points every error to its key
+func TestConfig_Validate(t *testing.T) {
+	cfg := Default()
+	cfg.Log.Level = "verbose"
+	cfg.Log.Format = "xml"
+	cfg.Tracing.Sampler = "sometimes"
+	cfg.Tracing.SamplerArg = 2
+	cfg.Redaction.Keys = []string{"password", ""}
+
+	err := cfg.Validate()
+	assert.Equal(t, []string{
+		"serviceName",
+		"log.level",
+		"log.format",
+		"tracing.sampler",
+		"tracing.samplerArg",
+		"redaction.keys[1]",
+	}, configErrorKeys(err))
+	assert.ErrorContains(t, err, `log.level: unknown level "verbose"`)
+}
+
+// Test that Load applies the environment variables over the file
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "observability.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(yamlConfig), 0o600))
+
+	t.Setenv("OBS_LOG_LEVEL", "error")
+	t.Setenv("OBS_REDACTION_KEYS", "secret, card")
+	cfg, err := Load(path, "OBS")
+	assert.NoError(t, err)
+	assert.Equal(t, "error", cfg.Log.Level)
+	assert.Equal(t, "console", cfg.Log.Format)
+	assert.Equal(t, []string{"secret", "card"}, cfg.Redaction.Keys)
+
+	// Assert invalid environment values point to the key and the variable
+	t.Setenv("OBS_TRACING_ENABLED", "maybe")
+	_, err = Load(path, "OBS")
+	var configErr *Error
+	assert.ErrorAs(t, err, &configErr)
+	assert.Equal(t, "tracing.enabled", configErr.Key)
+	assert.Equal(t, "OBS_TRACING_ENABLED", configErr.Source)
+
+	_, err = Load(filepath.Join(t.TempDir(), "observability.toml"), "")
+	assert.Error(t, err)
+}
+
+// Test that NewTracerProvider creates the configured provider and sampler
+func TestConfig_NewTracerProvider(t *testing.T) {
+	cfg, err := Parse([]byte(yamlConfig), "yaml")
+	assert.NoError(t, err)
+
+	tp, err := cfg.NewTracerProvider()
+	assert.NoError(t, err)
+	assert.IsType(t, &sdktrace.TracerProvider{}, tp)
+
+	// Assert the given options export the spans to other exporters
+	exporter := tracetest.NewInMemoryExporter()
+	tp, err = cfg.NewTracerProvider(sdktrace.WithSyncer(exporter), sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	assert.NoError(t, err)
+	_, span := tp.Tracer("checkout").Start(context.Background(), "POST /orders")
+	span.End()
+	assert.Len(t, exporter.GetSpans(), 1)
+
+	cfg.Tracing.Enabled = false
+	tp, err = cfg.NewTracerProvider()
+	assert.NoError(t, err)
+	assert.Nil(t, tp)
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// samplerNames are the samplers accepted by TracingConfig
+var samplerNames = []string{
+	"always_on",
+	"always_off",
+	"traceidratio",
+	"parentbased_always_on",
+	"parentbased_always_off",
+	"parentbased_traceidratio",
+}
+
+// NewTracerProvider creates the tracer provider described by the configuration
+// The given options are applied last, e.g. sdktrace.WithBatcher to export the spans to another exporter
+// Returns nil when tracing is disabled
+func (c Config) NewTracerProvider(opts ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
+	if !c.Tracing.Enabled {
+		return nil, nil
+	}
+
+	sampler, err := c.Tracing.sampler()
+	if err != nil {
+		return nil, &Error{Key: "tracing.sampler", Err: err}
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", c.ServiceName))),
+	}
+
+	switch c.Tracing.Exporter {
+	case "none":
+	case "stdout":
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, &Error{Key: "tracing.exporter", Err: err}
+		}
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, &Error{Key: "tracing.exporter", Err: fmt.Errorf("unknown exporter %q", c.Tracing.Exporter)}
+	}
+
+	return sdktrace.NewTracerProvider(append(providerOpts, opts...)...), nil
+}
+
+// sampler creates the configured sampler
+func (c TracingConfig) sampler() (sdktrace.Sampler, error) {
+	switch c.Sampler {
+	case "always_on":
+		return sdktrace.AlwaysSample(), nil
+	case "always_off":
+		return sdktrace.NeverSample(), nil
+	case "traceidratio":
+		return sdktrace.TraceIDRatioBased(c.SamplerArg), nil
+	case "parentbased_always_on":
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case "parentbased_always_off":
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case "parentbased_traceidratio":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SamplerArg)), nil
+	default:
+		return nil, fmt.Errorf("unknown sampler %q", c.Sampler)
+	}
+}
@@ -0,0 +1,19 @@
+package observability
+
+import "context"
+
+// handlerContextKey is the context key of the handlers of type T
+type handlerContextKey[T any] struct{}
+
+// ContextWithHandler returns a copy of ctx carrying the given handler
+// StartSpan stores the handler in the returned context so instrumented libraries can find it
+func ContextWithHandler[T any](ctx context.Context, h ObservabilityHandler[T]) context.Context {
+	return context.WithValue(ctx, handlerContextKey[T]{}, h)
+}
+
+// HandlerFromContext returns the handler of type T carried by ctx
+// Returns false if the context doesn't carry a handler of type T
+func HandlerFromContext[T any](ctx context.Context) (ObservabilityHandler[T], bool) {
+	h, ok := ctx.Value(handlerContextKey[T]{}).(ObservabilityHandler[T])
+	return h, ok
+}
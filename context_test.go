@@ -0,0 +1,62 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that StartSpan stores the handler in the returned context
+func TestHandlerFromContext(t *testing.T) {
+	_, ok := HandlerFromContext[string](context.Background())
+	assert.False(t, ok)
+
+	ctx, _ := newRecordingContext()
+	h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{})
+	spanCtx, end := h.StartSpan("GET /orders")
+	defer end()
+
+	found, ok := HandlerFromContext[string](spanCtx)
+	assert.True(t, ok)
+	assert.Same(t, h, found)
+
+	// Assert handlers of other types aren't returned
+	_, ok = HandlerFromContext[int](spanCtx)
+	assert.False(t, ok)
+}
+
+// Test that WithContext derives a handler starting children of the span in the context
+func TestObservabilityContext_WithContext(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{}
+	h := NewObservabilityHandler[string](ctx, "checkout", logger)
+	assert.NoError(t, h.SetTracingFormat(func(_ string, tv TraceValues) string { return tv.SpanId }))
+	spanCtx, end := h.StartSpan("GET /orders")
+	defer end()
+	parent, _ := h.GetTraceValues()
+
+	derived := h.WithContext(spanCtx)
+	// Assert the derived handler uses the span in the context until it starts its own
+	current, err := derived.GetTraceValues()
+	assert.NoError(t, err)
+	assert.Equal(t, parent.SpanId, current.SpanId)
+
+	_, endQuery := derived.StartSpan("SELECT")
+	query, _ := derived.GetTraceValues()
+	derived.LogInfo(derived.CreateLogBuilder().CreateLogValuesBuilder().WithMsg("query").Build())
+	endQuery()
+
+	assert.Equal(t, parent.SpanId, query.ParentSpanId)
+	assert.Equal(t, []string{query.SpanId}, logger.all()[0].lv.Values(InfoLevel))
+	assert.Equal(t, "SELECT", tp.all()[1].name)
+
+	// Assert the handler keeps its own span
+	after, _ := h.GetTraceValues()
+	assert.Equal(t, parent, after)
+	assert.Equal(t, []string{parent.SpanId}, h.CreateLogBuilder().CreateLogValuesBuilder().Build().Values(InfoLevel))
+
+	// Assert a context without span derives a handler without span
+	_, err = h.WithContext(context.Background()).GetTraceValues()
+	assert.Error(t, err)
+}
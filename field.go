@@ -0,0 +1,420 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// FieldKind is the kind of the value of a Field
+type FieldKind uint8
+
+const (
+	// AnyKind is the kind of the zero Field, whose value is nil
+	AnyKind FieldKind = iota
+	StringKind
+	IntKind
+	FloatKind
+	BoolKind
+	DurationKind
+	TimeKind
+	ErrorKind
+	// ObjectKind is the kind of the values without a dedicated kind, converted as is by the adapters
+	ObjectKind
+	// GroupKind is the kind of the fields nesting other fields, the nested fields are inlined when the key is empty
+	GroupKind
+)
+
+// String returns the name of the kind
+func (k FieldKind) String() string {
+	switch k {
+	case StringKind:
+		return "string"
+	case IntKind:
+		return "int"
+	case FloatKind:
+		return "float"
+	case BoolKind:
+		return "bool"
+	case DurationKind:
+		return "duration"
+	case TimeKind:
+		return "time"
+	case ErrorKind:
+		return "error"
+	case ObjectKind:
+		return "object"
+	case GroupKind:
+		return "group"
+	default:
+		return "any"
+	}
+}
+
+// Field is a key and a typed value independent of any logging backend
+//
+// Libraries log with LogValues[Field] so their consumers pick the backend,
+// the adapters convert the fields into their own type, e.g. the zap adapter ConvertField or FieldAttr for slog.
+type Field struct {
+	Key  string
+	Kind FieldKind
+	// num holds the int, bool and duration values and the bits of the float values
+	num int64
+	str string
+	// value holds the time, error, object and group values
+	value any
+}
+
+// String returns a string field
+func String(key, value string) Field {
+	return Field{Key: key, Kind: StringKind, str: value}
+}
+
+// Int returns an int field
+func Int(key string, value int) Field {
+	return Int64(key, int64(value))
+}
+
+// Int64 returns an int field
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Kind: IntKind, num: value}
+}
+
+// Uint64 returns an int field, or a string field holding the decimal value when it overflows an int64
+func Uint64(key string, value uint64) Field {
+	if value > math.MaxInt64 {
+		return String(key, strconv.FormatUint(value, 10))
+	}
+	return Int64(key, int64(value))
+}
+
+// Float64 returns a float field
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Kind: FloatKind, num: int64(math.Float64bits(value))}
+}
+
+// Bool returns a bool field
+func Bool(key string, value bool) Field {
+	var num int64
+	if value {
+		num = 1
+	}
+	return Field{Key: key, Kind: BoolKind, num: num}
+}
+
+// Duration returns a duration field
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Kind: DurationKind, num: int64(value)}
+}
+
+// Time returns a time field
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Kind: TimeKind, value: value}
+}
+
+// Err returns an error field with the error key
+func Err(err error) Field {
+	return NamedErr("error", err)
+}
+
+// NamedErr returns an error field with the given key
+func NamedErr(key string, err error) Field {
+	return Field{Key: key, Kind: ErrorKind, value: err}
+}
+
+// Object returns an object field, the value is converted as is by the adapters
+func Object(key string, value any) Field {
+	return Field{Key: key, Kind: ObjectKind, value: value}
+}
+
+// GroupOf returns a group field nesting the given fields under the key
+// The fields are inlined when the key is empty
+func GroupOf(key string, fields ...Field) Field {
+	return Field{Key: key, Kind: GroupKind, value: fields}
+}
+
+// Any returns a field of the kind matching the type of the value, an object field for unknown types
+// It can be used as the ValueConverter of handlers of fields
+func Any(key string, value any) Field {
+	switch v := value.(type) {
+	case Field:
+		return v
+	case string:
+		return String(key, v)
+	case int:
+		return Int(key, v)
+	case int8:
+		return Int64(key, int64(v))
+	case int16:
+		return Int64(key, int64(v))
+	case int32:
+		return Int64(key, int64(v))
+	case int64:
+		return Int64(key, v)
+	case uint8:
+		return Int64(key, int64(v))
+	case uint16:
+		return Int64(key, int64(v))
+	case uint32:
+		return Int64(key, int64(v))
+	case uint:
+		return Uint64(key, uint64(v))
+	case uint64:
+		return Uint64(key, v)
+	case uintptr:
+		return Uint64(key, uint64(v))
+	case float32:
+		return Float64(key, float64(v))
+	case float64:
+		return Float64(key, v)
+	case bool:
+		return Bool(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case time.Time:
+		return Time(key, v)
+	case error:
+		return NamedErr(key, v)
+	case []Field:
+		return GroupOf(key, v...)
+	default:
+		return Object(key, v)
+	}
+}
+
+// StringValue returns the value of a string field
+func (f Field) StringValue() string {
+	return f.str
+}
+
+// IntValue returns the value of an int field
+func (f Field) IntValue() int64 {
+	return f.num
+}
+
+// FloatValue returns the value of a float field
+func (f Field) FloatValue() float64 {
+	return math.Float64frombits(uint64(f.num))
+}
+
+// BoolValue returns the value of a bool field
+func (f Field) BoolValue() bool {
+	return f.num == 1
+}
+
+// DurationValue returns the value of a duration field
+func (f Field) DurationValue() time.Duration {
+	return time.Duration(f.num)
+}
+
+// TimeValue returns the value of a time field
+func (f Field) TimeValue() time.Time {
+	t, _ := f.value.(time.Time)
+	return t
+}
+
+// ErrorValue returns the value of an error field
+func (f Field) ErrorValue() error {
+	err, _ := f.value.(error)
+	return err
+}
+
+// GroupValue returns the nested fields of a group field
+func (f Field) GroupValue() []Field {
+	fields, _ := f.value.([]Field)
+	return fields
+}
+
+// Value returns the value of the field whatever its kind
+func (f Field) Value() any {
+	switch f.Kind {
+	case StringKind:
+		return f.str
+	case IntKind:
+		return f.num
+	case FloatKind:
+		return f.FloatValue()
+	case BoolKind:
+		return f.BoolValue()
+	case DurationKind:
+		return f.DurationValue()
+	default:
+		return f.value
+	}
+}
+
+// FieldFromAttribute returns the field of the given attribute
+// Slices are converted to object fields
+func FieldFromAttribute(kv attribute.KeyValue) Field {
+	key := string(kv.Key)
+	switch kv.Value.Type() {
+	case attribute.STRING:
+		return String(key, kv.Value.AsString())
+	case attribute.INT64:
+		return Int64(key, kv.Value.AsInt64())
+	case attribute.FLOAT64:
+		return Float64(key, kv.Value.AsFloat64())
+	case attribute.BOOL:
+		return Bool(key, kv.Value.AsBool())
+	default:
+		return Object(key, kv.Value.AsInterface())
+	}
+}
+
+// FieldAttributes returns the attributes of the given fields
+// Groups are flattened with their key as prefix, e.g. http.method
+// Durations, times, errors and objects are converted to strings
+func FieldAttributes(fields ...Field) []attribute.KeyValue {
+	return appendFieldAttributes(make([]attribute.KeyValue, 0, len(fields)), "", fields)
+}
+
+// appendFieldAttributes appends the attributes of the fields to dst with the given key prefix
+func appendFieldAttributes(dst []attribute.KeyValue, prefix string, fields []Field) []attribute.KeyValue {
+	for _, f := range fields {
+		key := attribute.Key(prefix + f.Key)
+		switch f.Kind {
+		case StringKind:
+			dst = append(dst, key.String(f.str))
+		case IntKind:
+			dst = append(dst, key.Int64(f.num))
+		case FloatKind:
+			dst = append(dst, key.Float64(f.FloatValue()))
+		case BoolKind:
+			dst = append(dst, key.Bool(f.BoolValue()))
+		case DurationKind:
+			dst = append(dst, key.String(f.DurationValue().String()))
+		case TimeKind:
+			dst = append(dst, key.String(f.TimeValue().Format(time.RFC3339Nano)))
+		case ErrorKind:
+			if err := f.ErrorValue(); err != nil {
+				dst = append(dst, key.String(err.Error()))
+			}
+		case GroupKind:
+			groupPrefix := prefix
+			if f.Key != "" {
+				groupPrefix += f.Key + "."
+			}
+			dst = appendFieldAttributes(dst, groupPrefix, f.GroupValue())
+		default:
+			dst = append(dst, key.String(fmt.Sprint(f.value)))
+		}
+	}
+	return dst
+}
+
+// FieldTracingFormat creates the tracing setup of handlers of fields rendering the keys of the given formatter
+// inline, so vendor keys such as dd.trace_id land at the top level of the log entry
+func FieldTracingFormat(formatter TracingFormatter) func(string, TraceValues) Field {
+	return func(_ string, tv TraceValues) Field {
+		attrs := formatter(tv)
+		fields := make([]Field, len(attrs))
+		for i, attr := range attrs {
+			fields[i] = FieldFromAttribute(attr)
+		}
+		return GroupOf("", fields...)
+	}
+}
+
+// FieldLogger is an ObservabilityLogger of fields converting them for a logger of another type,
+// so libraries logging fields can write through any adapter
+type FieldLogger[T any] struct {
+	logger  ObservabilityLogger[T]
+	convert func(Field) T
+}
+
+// NewFieldLogger creates a FieldLogger converting the fields with the given function, e.g. the zap adapter ConvertField
+func NewFieldLogger[T any](logger ObservabilityLogger[T], convert func(Field) T) *FieldLogger[T] {
+	return &FieldLogger[T]{logger: logger, convert: convert}
+}
+
+// Enabled checks if the wrapped logger emits logs at the given level
+func (l *FieldLogger[T]) Enabled(level Level) bool {
+	return l.logger.Enabled(level)
+}
+
+// LogInfo logs an info message with the converted values
+func (l *FieldLogger[T]) LogInfo(lv LogValues[Field]) {
+	l.logger.LogInfo(convertLogValues(lv, l.convert))
+}
+
+// LogError logs an error message with the converted values
+func (l *FieldLogger[T]) LogError(lv LogValues[Field]) {
+	l.logger.LogError(convertLogValues(lv, l.convert))
+}
+
+// LogDebug logs a debug message with the converted values
+func (l *FieldLogger[T]) LogDebug(lv LogValues[Field]) {
+	l.logger.LogDebug(convertLogValues(lv, l.convert))
+}
+
+// LogInfoContext logs an info message with the converted values and observability context
+func (l *FieldLogger[T]) LogInfoContext(ctx context.Context, lv LogValues[Field]) {
+	l.logger.LogInfoContext(ctx, convertLogValues(lv, l.convert))
+}
+
+// LogErrorContext logs an error message with the converted values and observability context
+func (l *FieldLogger[T]) LogErrorContext(ctx context.Context, lv LogValues[Field]) {
+	l.logger.LogErrorContext(ctx, convertLogValues(lv, l.convert))
+}
+
+// LogDebugContext logs a debug message with the converted values and observability context
+func (l *FieldLogger[T]) LogDebugContext(ctx context.Context, lv LogValues[Field]) {
+	l.logger.LogDebugContext(ctx, convertLogValues(lv, l.convert))
+}
+
+// Log logs a message at the given level with the converted values, refer to LevelLogger
+func (l *FieldLogger[T]) Log(ctx context.Context, level Level, lv LogValues[Field]) {
+	logCall[T]{level: level, ctx: ctx, lv: convertLogValues(lv, l.convert)}.dispatch(l.logger)
+}
+
+// RequiresSpanContext reports whether the wrapped logger requires the context of every call, refer to SpanContextLogger
+func (l *FieldLogger[T]) RequiresSpanContext() bool {
+	return requiresSpanContext(l.logger)
+}
+
+// SpanStarted forwards the start of the span to the wrapped logger if it's a SpanStartObserver
+func (l *FieldLogger[T]) SpanStarted(tv TraceValues) {
+	notifySpanStarted(l.logger, tv)
+}
+
+// SpanEnded forwards the end of the span to the wrapped logger if it's a SpanEndObserver
+func (l *FieldLogger[T]) SpanEnded(tv TraceValues) {
+	notifySpanEnded(l.logger, tv)
+}
+
+// convertLogValues returns the log values with every value converted, lazy values stay lazy
+func convertLogValues[F, T any](lv LogValues[F], convert func(F) T) LogValues[T] {
+	converted := LogValues[T]{
+		msg:   lv.msg,
+		err:   lv.err,
+		all:   convertLevelValues(lv.all, convert),
+		route: lv.route,
+	}
+	if len(lv.levels) > 0 {
+		converted.levels = make([]levelValues[T], len(lv.levels))
+		for i, levelValues := range lv.levels {
+			converted.levels[i] = convertLevelValues(levelValues, convert)
+		}
+	}
+	return converted
+}
+
+// convertLevelValues returns the values with every value converted, lazy values stay lazy
+func convertLevelValues[F, T any](v levelValues[F], convert func(F) T) levelValues[T] {
+	converted := levelValues[T]{level: v.level}
+	if len(v.values) > 0 {
+		converted.values = make([]T, len(v.values))
+		for i, value := range v.values {
+			converted.values[i] = convert(value)
+		}
+	}
+	for _, lazyValue := range v.lazy {
+		lazyValue := lazyValue
+		converted.lazy = append(converted.lazy, func() T { return convert(lazyValue()) })
+	}
+	return converted
+}
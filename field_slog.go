@@ -0,0 +1,37 @@
+//go:build go1.21
+
+package observability
+
+import "log/slog"
+
+// FieldAttr returns the slog attribute of the given field
+// Groups with an empty key are inlined by the slog handlers
+func FieldAttr(f Field) slog.Attr {
+	switch f.Kind {
+	case StringKind:
+		return slog.String(f.Key, f.str)
+	case IntKind:
+		return slog.Int64(f.Key, f.num)
+	case FloatKind:
+		return slog.Float64(f.Key, f.FloatValue())
+	case BoolKind:
+		return slog.Bool(f.Key, f.BoolValue())
+	case DurationKind:
+		return slog.Duration(f.Key, f.DurationValue())
+	case TimeKind:
+		return slog.Time(f.Key, f.TimeValue())
+	case GroupKind:
+		return slog.Attr{Key: f.Key, Value: slog.GroupValue(FieldAttrs(f.GroupValue()...)...)}
+	default:
+		return slog.Any(f.Key, f.value)
+	}
+}
+
+// FieldAttrs returns the slog attributes of the given fields
+func FieldAttrs(fields ...Field) []slog.Attr {
+	attrs := make([]slog.Attr, len(fields))
+	for i, f := range fields {
+		attrs[i] = FieldAttr(f)
+	}
+	return attrs
+}
@@ -0,0 +1,36 @@
+//go:build go1.21
+
+package observability
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that the fields are converted to slog attributes of the same kind
+func TestFieldAttrs(t *testing.T) {
+	err := errors.New("boom")
+	attrs := FieldAttrs(
+		String("name", "checkout"),
+		Int("items", 3),
+		Float64("ratio", 0.5),
+		Bool("retried", true),
+		Duration("elapsed", time.Second),
+		Err(err),
+		Object("ids", []int{1, 2}),
+		GroupOf("http", String("method", "GET")),
+	)
+
+	assert.True(t, attrs[0].Equal(slog.String("name", "checkout")))
+	assert.True(t, attrs[1].Equal(slog.Int64("items", 3)))
+	assert.True(t, attrs[2].Equal(slog.Float64("ratio", 0.5)))
+	assert.True(t, attrs[3].Equal(slog.Bool("retried", true)))
+	assert.True(t, attrs[4].Equal(slog.Duration("elapsed", time.Second)))
+	assert.Equal(t, err, attrs[5].Value.Any())
+	assert.Equal(t, []int{1, 2}, attrs[6].Value.Any())
+	assert.True(t, attrs[7].Equal(slog.Group("http", slog.String("method", "GET"))))
+}
@@ -0,0 +1,101 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Test that Any picks the kind matching the type of the value
+func TestAny(t *testing.T) {
+	now := time.Now()
+	err := errors.New("boom")
+	tests := map[FieldKind]Field{
+		StringKind:   Any("k", "v"),
+		IntKind:      Any("k", int32(3)),
+		FloatKind:    Any("k", 1.5),
+		BoolKind:     Any("k", true),
+		DurationKind: Any("k", time.Second),
+		TimeKind:     Any("k", now),
+		ErrorKind:    Any("k", err),
+		ObjectKind:   Any("k", []int{1}),
+		GroupKind:    Any("k", []Field{String("a", "b")}),
+	}
+	for kind, f := range tests {
+		assert.Equal(t, kind, f.Kind, kind.String())
+		assert.Equal(t, "k", f.Key)
+	}
+
+	assert.Equal(t, int64(3), tests[IntKind].IntValue())
+	assert.Equal(t, 1.5, tests[FloatKind].FloatValue())
+	assert.True(t, tests[BoolKind].BoolValue())
+	assert.Equal(t, time.Second, tests[DurationKind].DurationValue())
+	assert.Equal(t, now, tests[TimeKind].TimeValue())
+	assert.Equal(t, err, tests[ErrorKind].ErrorValue())
+	assert.Equal(t, []int{1}, tests[ObjectKind].Value())
+	assert.Equal(t, []Field{String("a", "b")}, tests[GroupKind].GroupValue())
+	// Assert the unsigned values are ints unless they overflow an int64
+	assert.Equal(t, Int64("k", 7), Any("k", uint(7)))
+	assert.Equal(t, Int64("k", math.MaxInt64), Any("k", uint64(math.MaxInt64)))
+	assert.Equal(t, Int64("k", 0x10), Any("k", uintptr(0x10)))
+	assert.Equal(t, String("k", "18446744073709551615"), Any("k", uint64(math.MaxUint64)))
+	// Assert fields are kept as is
+	assert.Equal(t, Int("other", 1), Any("k", Int("other", 1)))
+}
+
+// Test that the fields are converted to attributes with groups flattened
+func TestFieldAttributes(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("name", "checkout"),
+		attribute.Int64("items", 3),
+		attribute.String("elapsed", "1.5s"),
+		attribute.String("error", "boom"),
+		attribute.String("http.method", "GET"),
+		attribute.Bool("http.retried", false),
+		attribute.Float64("ratio", 0.5),
+	}, FieldAttributes(
+		String("name", "checkout"),
+		Int("items", 3),
+		Duration("elapsed", 1500*time.Millisecond),
+		Err(errors.New("boom")),
+		GroupOf("http", String("method", "GET"), Bool("retried", false)),
+		GroupOf("", Float64("ratio", 0.5)),
+	))
+}
+
+// Test that the tracing format renders the keys of the formatter as an inline group
+func TestFieldTracingFormat(t *testing.T) {
+	tv := TraceValues{TraceId: "5759e988bd862e3fe1be46a994272793", SpanId: "53995c3f42cd8ad8"}
+	f := FieldTracingFormat(OTelTracingFormatter())("tracing", tv)
+
+	assert.Equal(t, GroupKind, f.Kind)
+	assert.Empty(t, f.Key)
+	assert.Equal(t, []Field{String("trace_id", tv.TraceId), String("span_id", tv.SpanId)}, f.GroupValue())
+}
+
+// Test that the FieldLogger converts every value, including the lazy ones
+func TestFieldLogger(t *testing.T) {
+	logger := &recordingLogger{level: DebugLevel}
+	fieldLogger := NewFieldLogger[string](logger, func(f Field) string { return f.Key + "=" + f.StringValue() })
+	h := NewObservabilityHandler[Field](context.Background(), "checkout", fieldLogger, WithValueConverter[Field](Any))
+
+	h.LogInfo(NewLogValuesBuilder[Field]().
+		WithMsg("order").
+		WithAllLevelsValue(String("service", "checkout")).
+		WithInfoValue(String("id", "42")).
+		WithLazyValue(InfoLevel, func() Field { return String("lazy", "yes") }).
+		WithDebugValue(String("hidden", "yes")).
+		Build())
+	h.Infow("sugared", "id", "43")
+
+	entries := logger.all()
+	assert.Equal(t, []string{"order", "sugared"}, logger.messages())
+	assert.Equal(t, []string{"service=checkout", "id=42", "lazy=yes"}, entries[0].lv.Values(InfoLevel))
+	assert.Equal(t, []string{"hidden=yes"}, entries[0].lv.LevelValues(DebugLevel))
+	assert.Equal(t, []string{"id=43"}, entries[1].lv.Values(InfoLevel))
+}
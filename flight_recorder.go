@@ -0,0 +1,294 @@
+package observability
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// FlightRecord is a log kept by a FlightRecorder
+type FlightRecord[T any] struct {
+	Time  time.Time
+	Level Level
+	Msg   string
+	Err   error
+	// TraceId and SpanId are empty when the log was written outside of a span
+	TraceId string
+	SpanId  string
+	// Values are the values of the level, lazy values are evaluated when the records are read
+	Values []T
+
+	// all and logged are the values attached to every level and to the level of the log,
+	// kept until the records are read so lazy values are only evaluated when dumped
+	all, logged levelValues[T]
+}
+
+const (
+	// RecordTimeKey is the key of the time of the record added by DumpTo
+	RecordTimeKey = "recorded_at"
+	// RecordLevelKey is the key of the original level of the record added by DumpTo
+	RecordLevelKey = "recorded_level"
+	// RecordTraceIdKey is the key of the trace id of the record added by DumpTo
+	RecordTraceIdKey = "trace_id"
+	// RecordSpanIdKey is the key of the span id of the record added by DumpTo
+	RecordSpanIdKey = "span_id"
+)
+
+// FlightRecorder keeps the last logs of a handler in a ring buffer, whatever their level is enabled or not,
+// to dump them for post-mortem debugging when an error or a panic happens or on demand
+//
+// The handler records the logs after running its hooks, so redacted values stay redacted,
+// and runs the hooks of the disabled levels too while a recorder is set.
+type FlightRecorder[T any] struct {
+	// encodeValues converts the values of a record into their JSON representation
+	encodeValues func(values []T) any
+	// convert creates the values of the time, level and ids of the records dumped by DumpTo, they're omitted when nil
+	convert ValueConverter[T]
+	// onError is called by the handler after a log at the error level is written, nothing is done when nil
+	onError func(r *FlightRecorder[T])
+
+	mu      sync.Mutex
+	records []FlightRecord[T]
+	// next is the index of the next record to write, the oldest record once the buffer is full
+	next int
+	full bool
+	// total is the number of logs recorded so far and dumped the total when onError was last called
+	total  uint64
+	dumped uint64
+}
+
+// FlightRecorderOption configures a FlightRecorder
+type FlightRecorderOption[T any] func(*FlightRecorder[T])
+
+// WithRecordValuesEncoder sets the conversion of the values of a record into their JSON representation,
+// every value is formatted with fmt.Sprint by default
+func WithRecordValuesEncoder[T any](encode func(values []T) any) FlightRecorderOption[T] {
+	return func(r *FlightRecorder[T]) {
+		r.encodeValues = encode
+	}
+}
+
+// WithRecordValueConverter sets the converter creating the values of the time, the original level
+// and the trace and span ids of the records dumped by DumpTo, e.g. zap.Any
+func WithRecordValueConverter[T any](convert ValueConverter[T]) FlightRecorderOption[T] {
+	return func(r *FlightRecorder[T]) {
+		r.convert = convert
+	}
+}
+
+// WithDumpOnError sets the function called by the handler once a log at the error level is written,
+// e.g. func(r *FlightRecorder[T]) { r.DumpToFile(path) }
+// It's called with a recorder holding only the records not dumped by its previous calls,
+// so a burst of errors doesn't dump the same records again.
+// It runs synchronously in the goroutine writing the log, the panics recovered by Trace are logged as errors
+func WithDumpOnError[T any](dump func(r *FlightRecorder[T])) FlightRecorderOption[T] {
+	return func(r *FlightRecorder[T]) {
+		r.onError = dump
+	}
+}
+
+// NewFlightRecorder creates a FlightRecorder keeping the last size logs
+func NewFlightRecorder[T any](size int, opts ...FlightRecorderOption[T]) *FlightRecorder[T] {
+	if size < 1 {
+		size = 1
+	}
+	r := &FlightRecorder[T]{
+		encodeValues: sprintValues[T],
+		records:      make([]FlightRecord[T], size),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// record adds a log to the buffer, overwriting the oldest one once full
+func (r *FlightRecorder[T]) record(level Level, tv TraceValues, lv LogValues[T]) {
+	rec := FlightRecord[T]{
+		Time:    time.Now(),
+		Level:   level,
+		Msg:     lv.Msg(),
+		Err:     lv.Err(),
+		TraceId: tv.TraceId,
+		SpanId:  tv.SpanId,
+		// the values are copied as the log values can come from a pooled builder
+		all:    lv.all.copied(),
+		logged: lv.level(level).copied(),
+	}
+
+	r.mu.Lock()
+	r.records[r.next] = rec
+	r.next++
+	if r.next == len(r.records) {
+		r.next, r.full = 0, true
+	}
+	r.total++
+	r.mu.Unlock()
+}
+
+// errorRecorded calls the onError function with the records not dumped yet after a log at the error level was recorded
+func (r *FlightRecorder[T]) errorRecorded() {
+	if r.onError == nil {
+		return
+	}
+
+	r.mu.Lock()
+	records := r.ordered()
+	if undumped := r.total - r.dumped; undumped < uint64(len(records)) {
+		records = records[len(records)-int(undumped):]
+	}
+	r.dumped = r.total
+	r.mu.Unlock()
+
+	if len(records) == 0 {
+		return
+	}
+	r.onError(&FlightRecorder[T]{
+		encodeValues: r.encodeValues,
+		convert:      r.convert,
+		records:      records,
+		next:         len(records),
+		full:         true,
+	})
+}
+
+// Records returns the recorded logs from the oldest to the newest
+// The lazy values are evaluated on every call
+func (r *FlightRecorder[T]) Records() []FlightRecord[T] {
+	r.mu.Lock()
+	records := r.ordered()
+	r.mu.Unlock()
+
+	for i := range records {
+		records[i].Values = records[i].logged.appendTo(records[i].all.appendTo(nil))
+	}
+	return records
+}
+
+// ordered returns a copy of the records from the oldest to the newest
+// Requires the lock to be held
+func (r *FlightRecorder[T]) ordered() []FlightRecord[T] {
+	if !r.full {
+		return append([]FlightRecord[T](nil), r.records[:r.next]...)
+	}
+	records := make([]FlightRecord[T], 0, len(r.records))
+	records = append(records, r.records[r.next:]...)
+	return append(records, r.records[:r.next]...)
+}
+
+// DumpTo writes the recorded logs to the logger at the given level, whatever their own level,
+// so the logs of the disabled levels are written too
+// The time, the original level and the ids of the records are added with the keys Record*Key
+// when a converter is set, refer to WithRecordValueConverter
+func (r *FlightRecorder[T]) DumpTo(logger ObservabilityLogger[T], level Level) {
+	for _, rec := range r.Records() {
+		lv := NewLogValuesBuilder[T]().WithMsg(rec.Msg).WithErr(rec.Err)
+		if r.convert != nil {
+			lv.WithValue(level, r.convert(RecordTimeKey, rec.Time))
+			lv.WithValue(level, r.convert(RecordLevelKey, rec.Level.String()))
+			if rec.TraceId != "" {
+				lv.WithValue(level, r.convert(RecordTraceIdKey, rec.TraceId))
+				lv.WithValue(level, r.convert(RecordSpanIdKey, rec.SpanId))
+			}
+		}
+		for _, value := range rec.Values {
+			lv.WithValue(level, value)
+		}
+		logCall[T]{level: level, lv: lv.Build()}.dispatch(logger)
+	}
+}
+
+// flightRecordJSON is the JSON representation of a FlightRecord
+type flightRecordJSON struct {
+	Time    time.Time `json:"time"`
+	Level   Level     `json:"level"`
+	Msg     string    `json:"msg"`
+	Err     string    `json:"error,omitempty"`
+	TraceId string    `json:"trace_id,omitempty"`
+	SpanId  string    `json:"span_id,omitempty"`
+	Values  any       `json:"values,omitempty"`
+}
+
+// WriteTo writes the recorded logs to w as JSON lines, from the oldest to the newest
+func (r *FlightRecorder[T]) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	enc := json.NewEncoder(cw)
+	for _, rec := range r.Records() {
+		line := flightRecordJSON{
+			Time:    rec.Time,
+			Level:   rec.Level,
+			Msg:     rec.Msg,
+			TraceId: rec.TraceId,
+			SpanId:  rec.SpanId,
+		}
+		if rec.Err != nil {
+			line.Err = rec.Err.Error()
+		}
+		if len(rec.Values) > 0 {
+			line.Values = r.encodeValues(rec.Values)
+		}
+		if err := enc.Encode(line); err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, nil
+}
+
+// DumpToFile appends the recorded logs to the file at the given path as JSON lines
+func (r *FlightRecorder[T]) DumpToFile(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := r.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// DumpOnPanic dumps the recorded logs with the given function when the goroutine panics
+// and panics again, it must be deferred directly, e.g. defer r.DumpOnPanic(dump)
+func (r *FlightRecorder[T]) DumpOnPanic(dump func(r *FlightRecorder[T])) {
+	if v := recover(); v != nil {
+		dump(r)
+		panic(v)
+	}
+}
+
+// ServeHTTP exposes the recorded logs as an admin endpoint, GET returns them as JSON lines
+func (r *FlightRecorder[T]) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		writeLevelJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	_, _ = r.WriteTo(w)
+}
+
+// sprintValues formats every value with fmt.Sprint
+func sprintValues[T any](values []T) any {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = fmt.Sprint(value)
+	}
+	return formatted
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes p to the underlying writer
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,199 @@
+package observability
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordedMessages(records []FlightRecord[string]) []string {
+	var msgs []string
+	for _, rec := range records {
+		msgs = append(msgs, rec.Msg)
+	}
+	return msgs
+}
+
+// Test that the recorder keeps the last logs of every level, including the disabled ones
+func TestFlightRecorder_Ring(t *testing.T) {
+	logger := &recordingLogger{level: DebugLevel}
+	recorder := NewFlightRecorder[string](3)
+	h := NewObservabilityHandler[string](context.Background(), "checkout", logger,
+		WithFlightRecorder[string](recorder), WithLevelController[string](NewLevelController(InfoLevel)))
+
+	h.LogDebug(msg("first"))
+	h.LogInfo(msg("second"))
+	assert.Equal(t, []string{"first", "second"}, recordedMessages(recorder.Records()))
+
+	h.LogDebug(NewLogValuesBuilder[string]().WithMsg("third").WithDebugValue("cart=3").WithInfoValue("hidden").Build())
+	h.LogInfo(msg("fourth"))
+	records := recorder.Records()
+	assert.Equal(t, []string{"second", "third", "fourth"}, recordedMessages(records))
+	assert.Equal(t, DebugLevel, records[1].Level)
+	assert.Equal(t, []string{"cart=3"}, records[1].Values)
+	// Assert the disabled levels are still not logged
+	assert.Equal(t, []string{"second", "fourth"}, logger.messages())
+}
+
+// Test that the logs are recorded once the hooks ran and with the ids of the span
+func TestFlightRecorder_HooksAndSpan(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	recorder := NewFlightRecorder[string](10)
+	veto := func(_ context.Context, _ Level, lv LogValues[string]) (LogValues[string], bool) {
+		return lv, lv.Msg() != "vetoed"
+	}
+	h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{level: ErrorLevel},
+		WithFlightRecorder[string](recorder), WithLogHooks[string](veto))
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	h.LogDebugContext(msg("vetoed"))
+	h.LogDebugContext(msg("kept"))
+
+	records := recorder.Records()
+	tv, _ := h.GetTraceValues()
+	assert.Equal(t, []string{"kept"}, recordedMessages(records))
+	assert.Equal(t, tv.TraceId, records[0].TraceId)
+	assert.Equal(t, tv.SpanId, records[0].SpanId)
+}
+
+// Test that the records are dumped once an error is logged with their time, level and ids
+func TestFlightRecorder_DumpOnError(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	dump := &recordingLogger{level: DebugLevel}
+	var loggedBeforeDump []string
+	recorder := NewFlightRecorder[string](10,
+		WithRecordValueConverter[string](func(key string, value any) string { return fmt.Sprintf("%s=%v", key, value) }),
+		WithDumpOnError[string](func(r *FlightRecorder[string]) {
+			loggedBeforeDump = logger.messages()
+			r.DumpTo(dump, InfoLevel)
+		}))
+	h := NewObservabilityHandler[string](ctx, "checkout", logger,
+		WithFlightRecorder[string](recorder), WithLevelController[string](NewLevelController(InfoLevel)))
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+	tv, _ := h.GetTraceValues()
+
+	h.LogDebug(NewLogValuesBuilder[string]().WithMsg("loading cart").WithDebugValue("cart=3").Build())
+	assert.Empty(t, dump.all())
+
+	h.LogError(NewLogValuesBuilder[string]().WithMsg("card declined").WithErr(errors.New("declined")).Build())
+	assert.Equal(t, []string{"card declined"}, loggedBeforeDump)
+	assert.Equal(t, []string{"loading cart", "card declined"}, dump.messages())
+	for _, entry := range dump.all() {
+		assert.Equal(t, InfoLevel, entry.level)
+	}
+	assert.EqualError(t, dump.all()[1].lv.Err(), "declined")
+
+	records := recorder.Records()
+	assert.Equal(t, []string{
+		"recorded_at=" + fmt.Sprint(records[0].Time),
+		"recorded_level=debug",
+		"trace_id=" + tv.TraceId,
+		"span_id=" + tv.SpanId,
+		"cart=3",
+	}, dump.all()[0].lv.Values(InfoLevel))
+	assert.Equal(t, "recorded_level=error", dump.all()[1].lv.Values(InfoLevel)[1])
+
+	// Assert the next error only dumps the records not dumped yet
+	h.LogError(msg("card declined again"))
+	assert.Equal(t, []string{"loading cart", "card declined", "card declined again"}, dump.messages())
+
+	// Assert the records are dumped without the time, level and ids when no converter is set
+	dump = &recordingLogger{level: DebugLevel}
+	recorder.convert = nil
+	recorder.DumpTo(dump, InfoLevel)
+	assert.Equal(t, []string{"cart=3"}, dump.all()[0].lv.Values(InfoLevel))
+}
+
+// Test that the lazy values of the disabled levels are only evaluated when the records are read
+// and that the timers of the disabled levels are recorded
+func TestFlightRecorder_LazyValuesAndTimers(t *testing.T) {
+	recorder := NewFlightRecorder[string](10)
+	h := NewObservabilityHandler[string](context.Background(), "checkout", &recordingLogger{level: DebugLevel},
+		WithFlightRecorder[string](recorder), WithLevelController[string](NewLevelController(InfoLevel)))
+
+	evaluated := 0
+	h.LogDebug(NewLogValuesBuilder[string]().
+		WithMsg("loading cart").
+		WithLazyValue(DebugLevel, func() string { evaluated++; return "cart=3" }).
+		Build())
+	assert.Zero(t, evaluated)
+
+	h.StartTimer("load cart").WithLevel(DebugLevel).Stop(OutcomeSuccess)
+	records := recorder.Records()
+	assert.Equal(t, 1, evaluated)
+	assert.Equal(t, []string{"loading cart", "load cart"}, recordedMessages(records))
+	assert.Equal(t, []string{"cart=3"}, records[0].Values)
+	assert.Equal(t, DebugLevel, records[1].Level)
+}
+
+// Test that the panics recovered by Trace trigger the dump
+func TestFlightRecorder_DumpOnTracedPanic(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	dumps := 0
+	recorder := NewFlightRecorder[string](10, WithDumpOnError[string](func(*FlightRecorder[string]) { dumps++ }))
+	h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{level: InfoLevel}, WithFlightRecorder[string](recorder))
+
+	assert.Panics(t, func() {
+		_ = TraceVoid(h, "charge", func(context.Context) error { panic("boom") })
+	})
+	assert.Equal(t, 1, dumps)
+}
+
+// Test that DumpOnPanic dumps the records and panics again
+func TestFlightRecorder_DumpOnPanic(t *testing.T) {
+	recorder := NewFlightRecorder[string](10)
+	h := NewObservabilityHandler[string](context.Background(), "checkout", &recordingLogger{level: InfoLevel}, WithFlightRecorder[string](recorder))
+	h.LogDebug(msg("loading cart"))
+
+	var dumped []string
+	assert.PanicsWithValue(t, "boom", func() {
+		defer recorder.DumpOnPanic(func(r *FlightRecorder[string]) { dumped = recordedMessages(r.Records()) })
+		panic("boom")
+	})
+	assert.Equal(t, []string{"loading cart"}, dumped)
+}
+
+// Test that the records are written as JSON lines to writers, files and the admin endpoint
+func TestFlightRecorder_WriteTo(t *testing.T) {
+	recorder := NewFlightRecorder[string](10)
+	h := NewObservabilityHandler[string](context.Background(), "checkout", &recordingLogger{level: InfoLevel}, WithFlightRecorder[string](recorder))
+	h.LogDebug(NewLogValuesBuilder[string]().WithMsg("loading cart").WithDebugValue("cart=3").Build())
+	h.LogError(NewLogValuesBuilder[string]().WithMsg("card declined").WithErr(errors.New("declined")).Build())
+
+	var buf bytes.Buffer
+	n, err := recorder.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"level":"debug","msg":"loading cart","values":["cart=3"]`)
+	assert.Contains(t, lines[1], `"level":"error","msg":"card declined","error":"declined"`)
+
+	path := filepath.Join(t.TempDir(), "flight.log")
+	assert.NoError(t, recorder.DumpToFile(path))
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, buf.String(), string(content))
+
+	rec := httptest.NewRecorder()
+	recorder.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/flight", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	assert.Equal(t, buf.String(), rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	recorder.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/flight", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
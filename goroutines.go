@@ -0,0 +1,92 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Go runs fn in a goroutine in a child span of the handler's span, refer to Trace
+// The context given to fn carries the goroutine handler, refer to HandlerFromContext
+// Panics are recorded and returned as a PanicError instead of crashing the process
+// The returned channel receives the error of fn, nil on success, and is closed afterwards
+func Go[T any](h ObservabilityHandler[T], name string, fn func(ctx context.Context) error) <-chan error {
+	done := make(chan error, 1)
+	// the handler is derived before starting the goroutine, handlers aren't safe for concurrent use
+	derived := h.WithContext(h.Context())
+	go func() {
+		defer close(done)
+		done <- goTraced(derived, name, fn)
+	}()
+	return done
+}
+
+// Group runs goroutines in child spans of the handler's span and waits for them, like errgroup.Group
+// The context of the group is canceled once a goroutine fails
+type Group[T any] struct {
+	handler ObservabilityHandler[T]
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+
+	wg   sync.WaitGroup
+	sem  chan struct{}
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewGroup creates a Group whose goroutines are children of the handler's span
+// The returned context is canceled once a goroutine fails or Wait returns
+func NewGroup[T any](h ObservabilityHandler[T]) (*Group[T], context.Context) {
+	ctx, cancel := context.WithCancelCause(h.Context())
+	return &Group[T]{handler: h, ctx: ctx, cancel: cancel}, ctx
+}
+
+// SetLimit limits the number of goroutines running at once, Go blocks until one finishes
+// Must be called before Go, a negative limit removes it
+func (g *Group[T]) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs fn in a goroutine in a child span, refer to the Go function
+func (g *Group[T]) Go(name string, fn func(ctx context.Context) error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	derived := g.handler.WithContext(g.ctx)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		if err := goTraced(derived, name, fn); err != nil {
+			g.mu.Lock()
+			g.errs = append(g.errs, err)
+			g.mu.Unlock()
+			g.cancel(err)
+		}
+	}()
+}
+
+// Wait waits for the goroutines and returns their errors joined, nil when every goroutine succeeded
+func (g *Group[T]) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
+
+// goTraced runs fn in a child span of the handler's span recovering the panics
+func goTraced[T any](h ObservabilityHandler[T], name string, fn func(ctx context.Context) error) error {
+	_, err := traced(h, h.Context(), name, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, true, nil)
+	return err
+}
@@ -0,0 +1,87 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that Go runs the function in a child span with its own handler and recovers the panics
+func TestGo(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{}
+	h := NewObservabilityHandler[string](ctx, "checkout", logger)
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+	parent, _ := h.GetTraceValues()
+
+	err := <-Go(h, "send email", func(ctx context.Context) error {
+		gh, ok := HandlerFromContext[string](ctx)
+		assert.True(t, ok)
+		gh.LogInfo(msg("sending"))
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = <-Go(h, "explode", func(context.Context) error { panic("boom") })
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+
+	spans := tp.all()
+	assert.Equal(t, parent.SpanId, spans[1].parent.SpanID().String())
+	assert.Equal(t, "sending", spans[1].recordedEvents()[0].name)
+	assert.True(t, spans[2].ended)
+	assert.Equal(t, []string{"sending", "explode"}, logger.messages())
+}
+
+// Test that the group waits for the goroutines, joins their errors and cancels its context
+func TestGroup(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{})
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	g, groupCtx := NewGroup(h)
+	g.SetLimit(1)
+	var done atomic.Int32
+	g.Go("reserve stock", func(context.Context) error {
+		done.Add(1)
+		return nil
+	})
+	g.Go("charge card", func(context.Context) error { return errors.New("declined") })
+	g.Go("notify", func(context.Context) error { panic("boom") })
+
+	err := g.Wait()
+	assert.ErrorContains(t, err, "declined")
+	assert.ErrorContains(t, err, "panic: boom")
+	assert.Equal(t, int32(1), done.Load())
+	// Assert the context is canceled with the first error, the limit runs the goroutines one at a time
+	assert.EqualError(t, context.Cause(groupCtx), "declined")
+
+	var names []string
+	for _, span := range tp.all()[1:] {
+		names = append(names, span.name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"charge card", "notify", "reserve stock"}, names)
+}
+
+// Test that the group succeeds when every goroutine succeeds
+func TestGroup_Success(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{})
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	g, groupCtx := NewGroup(h)
+	for i := 0; i < 3; i++ {
+		g.Go("work", func(context.Context) error { return nil })
+	}
+	assert.NoError(t, g.Wait())
+	// Assert the context is canceled once Wait returns
+	assert.Error(t, groupCtx.Err())
+}
@@ -0,0 +1,67 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/sosalejandro/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Consumer starts the consumer spans of the received messages
+type Consumer[T any] struct {
+	handler observability.ObservabilityHandler[T]
+	cfg     *config
+}
+
+// NewConsumer creates a Consumer deriving a handler per message from the given handler
+func NewConsumer[T any](handler observability.ObservabilityHandler[T], opts ...Option) *Consumer[T] {
+	return &Consumer[T]{handler: handler, cfg: newConfig(opts)}
+}
+
+// Start extracts the context of a message received from the destination and starts its consumer span
+// The span is a child of the producer span linked to it, or a new root linked to it with WithLinkedRoot
+// Returns the context and the handler of the message, finish ends the span marking it as failed when err is set
+func (c *Consumer[T]) Start(ctx context.Context, destination string, headers Headers, attrs ...attribute.KeyValue) (context.Context, observability.ObservabilityHandler[T], func(err error)) {
+	ctx = c.cfg.textMapPropagator().Extract(ctx, headers)
+
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(spanAttributes(c.cfg, destination, "process", attrs)...),
+	}
+	if producer := trace.SpanContextFromContext(ctx); producer.IsValid() {
+		opts = append(opts, trace.WithLinks(observability.LinkFromSpanContext(producer)))
+		if c.cfg.linkedRoot {
+			opts = append(opts, trace.WithNewRoot())
+		}
+	}
+
+	return c.start(ctx, destination+" process", opts)
+}
+
+// StartBatch starts a consumer span for a batch of messages received from the destination
+// The span is linked to the producer span of every message carrying a valid context
+// Returns the context and the handler of the batch, finish ends the span marking it as failed when err is set
+func (c *Consumer[T]) StartBatch(ctx context.Context, destination string, headers []Headers, attrs ...attribute.KeyValue) (context.Context, observability.ObservabilityHandler[T], func(err error)) {
+	propagator := c.cfg.textMapPropagator()
+	links := make([]trace.Link, 0, len(headers))
+	for _, h := range headers {
+		if link, ok := observability.LinkFromCarrier(ctx, propagator, h); ok {
+			links = append(links, link)
+		}
+	}
+
+	attrs = append([]attribute.KeyValue{observability.MessagingBatchMessageCount(len(headers))}, attrs...)
+	return c.start(ctx, destination+" process", []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(spanAttributes(c.cfg, destination, "process", attrs)...),
+		trace.WithLinks(links...),
+	})
+}
+
+// start starts the span in a handler derived for the message
+func (c *Consumer[T]) start(ctx context.Context, name string, opts []trace.SpanStartOption) (context.Context, observability.ObservabilityHandler[T], func(error)) {
+	h := c.handler.WithContext(ctx)
+	ctx, end := h.StartSpan(name, opts...)
+	return ctx, h, finisher(ctx, end)
+}
@@ -0,0 +1,96 @@
+// Package messaging instruments message producers and consumers of any broker, e.g. NATS, Kafka or SQS
+//
+// Producers start producer spans under the ObservabilityHandler carried by the context and inject their context
+// into the message headers, consumers extract it to start consumer spans and hand back a handler per message.
+package messaging
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Headers carries the headers of a message, adapters implement it on top of the broker headers
+// Any propagation.TextMapCarrier is a Headers
+type Headers interface {
+	// Get returns the value of the key, empty when missing
+	Get(key string) string
+	// Set sets the value of the key
+	Set(key, value string)
+	// Keys returns the keys of the headers
+	Keys() []string
+}
+
+// MapHeaders are headers stored in a map, e.g. for brokers exposing headers as map[string]string
+type MapHeaders map[string]string
+
+// Get returns the value of the key, empty when missing
+func (h MapHeaders) Get(key string) string {
+	return h[key]
+}
+
+// Set sets the value of the key
+func (h MapHeaders) Set(key, value string) {
+	h[key] = value
+}
+
+// Keys returns the keys of the headers
+func (h MapHeaders) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Option configures the producers and consumers
+type Option func(*config)
+
+// config contains the settings of the producers and consumers
+type config struct {
+	// system is the messaging.system attribute, e.g. kafka
+	system string
+	// propagator injects and extracts the context, the global propagator is used when nil
+	propagator propagation.TextMapPropagator
+	// linkedRoot starts the consumer spans as new roots linked to the producer spans
+	linkedRoot bool
+}
+
+// WithSystem sets the messaging.system attribute of the spans, e.g. kafka
+func WithSystem(system string) Option {
+	return func(c *config) {
+		c.system = system
+	}
+}
+
+// WithPropagator sets the propagator injecting and extracting the context from the headers
+// The global propagator is used by default, refer to otel.SetTextMapPropagator
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// WithLinkedRoot starts the consumer spans as new traces linked to the producer spans
+// instead of children of the producer spans
+func WithLinkedRoot() Option {
+	return func(c *config) {
+		c.linkedRoot = true
+	}
+}
+
+// newConfig creates the config with the given options
+func newConfig(opts []Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// textMapPropagator returns the configured propagator or the global one
+func (c *config) textMapPropagator() propagation.TextMapPropagator {
+	if c.propagator == nil {
+		return otel.GetTextMapPropagator()
+	}
+	return c.propagator
+}
@@ -0,0 +1,136 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sosalejandro/observability"
+	"github.com/sosalejandro/observability/internal/spantest"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// message is a message of memoryBroker
+type message struct {
+	headers MapHeaders
+	body    string
+}
+
+// memoryBroker delivers the published messages in order
+type memoryBroker struct {
+	messages chan message
+}
+
+func (b *memoryBroker) publish(ctx context.Context, producer *Producer[string], body string) {
+	headers := MapHeaders{}
+	_, finish := producer.Start(ctx, "orders", headers, observability.MessagingMessageBodySize(len(body)))
+	b.messages <- message{headers: headers, body: body}
+	finish(nil)
+}
+
+// nopLogger is an ObservabilityLogger discarding every call
+type nopLogger struct{}
+
+func (nopLogger) Enabled(observability.Level) bool                                 { return true }
+func (nopLogger) LogInfo(observability.LogValues[string])                          {}
+func (nopLogger) LogError(observability.LogValues[string])                         {}
+func (nopLogger) LogDebug(observability.LogValues[string])                         {}
+func (nopLogger) LogInfoContext(context.Context, observability.LogValues[string])  {}
+func (nopLogger) LogErrorContext(context.Context, observability.LogValues[string]) {}
+func (nopLogger) LogDebugContext(context.Context, observability.LogValues[string]) {}
+
+// setup returns a handler recording its spans and a broker
+func setup() (observability.ObservabilityHandler[string], *spantest.Recorder, *memoryBroker) {
+	recorder := spantest.NewRecorder()
+	h := observability.NewObservabilityHandler[string](context.Background(), "orders", nopLogger{}, observability.WithTracerProvider[string](recorder))
+	return h, recorder, &memoryBroker{messages: make(chan message, 10)}
+}
+
+// Test that the consumer spans continue the trace of the producer spans
+func TestProducerConsumer(t *testing.T) {
+	h, recorder, broker := setup()
+	opts := []Option{WithSystem("memory"), WithPropagator(propagation.TraceContext{})}
+
+	ctx, end := h.StartSpan("POST /orders")
+	broker.publish(ctx, NewProducer[string](opts...), "order")
+	end()
+	request, _ := h.GetTraceValues()
+
+	msg := <-broker.messages
+	assert.NotEmpty(t, msg.headers.Get("traceparent"))
+
+	_, mh, finish := NewConsumer[string](h, opts...).Start(context.Background(), "orders", msg.headers)
+	processed, _ := mh.GetTraceValues()
+	finish(errors.New("invalid order"))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+	producer, consumer := spans[0], spans[2]
+	assert.Equal(t, "orders publish", producer.Name())
+	assert.Equal(t, trace.SpanKindProducer, producer.SpanKind())
+	assert.Equal(t, request.SpanId, producer.Parent().SpanID().String())
+
+	// Assert the consumer span is a child of the producer span linked to it
+	assert.Equal(t, "orders process", consumer.Name())
+	assert.Equal(t, trace.SpanKindConsumer, consumer.SpanKind())
+	assert.Equal(t, request.TraceId, processed.TraceId)
+	assert.Equal(t, producer.SpanContext().SpanID().String(), processed.ParentSpanId)
+	assert.True(t, processed.Remote)
+	assert.Equal(t, producer.SpanContext().SpanID(), consumer.Links()[0].SpanContext.SpanID())
+	assert.Equal(t, codes.Error, consumer.Status().Code)
+	assert.Contains(t, consumer.Attributes(), observability.MessagingSystem("memory"))
+}
+
+// Test that WithLinkedRoot starts the consumer spans in new traces
+func TestConsumer_LinkedRoot(t *testing.T) {
+	h, recorder, broker := setup()
+	opts := []Option{WithPropagator(propagation.TraceContext{})}
+
+	ctx, end := h.StartSpan("POST /orders")
+	broker.publish(ctx, NewProducer[string](opts...), "order")
+	end()
+
+	_, mh, finish := NewConsumer[string](h, append(opts, WithLinkedRoot())...).Start(context.Background(), "orders", (<-broker.messages).headers)
+	processed, _ := mh.GetTraceValues()
+	finish(nil)
+
+	producer := recorder.Ended()[0]
+	assert.NotEqual(t, producer.SpanContext().TraceID().String(), processed.TraceId)
+	assert.Empty(t, processed.ParentSpanId)
+	assert.Equal(t, producer.SpanContext().SpanID(), recorder.Ended()[2].Links()[0].SpanContext.SpanID())
+}
+
+// Test that batch consumer spans are linked to the producer span of every message
+func TestConsumer_StartBatch(t *testing.T) {
+	h, recorder, broker := setup()
+	opts := []Option{WithPropagator(propagation.TraceContext{})}
+	producer := NewProducer[string](opts...)
+
+	ctx, end := h.StartSpan("POST /orders")
+	broker.publish(ctx, producer, "first")
+	broker.publish(ctx, producer, "second")
+	end()
+
+	batch := []Headers{(<-broker.messages).headers, (<-broker.messages).headers, MapHeaders{}}
+	_, _, finish := NewConsumer[string](h, opts...).StartBatch(context.Background(), "orders", batch)
+	finish(nil)
+
+	spans := recorder.Ended()
+	consumer := spans[len(spans)-1]
+	assert.Len(t, consumer.Links(), 2)
+	assert.Contains(t, consumer.Attributes(), observability.MessagingBatchMessageCount(3))
+}
+
+// Test that the context is injected as is without handler in the context
+func TestProducer_WithoutHandler(t *testing.T) {
+	_, recorder, _ := setup()
+	headers := MapHeaders{}
+	_, finish := NewProducer[string](WithPropagator(propagation.TraceContext{})).Start(context.Background(), "orders", headers)
+	finish(nil)
+
+	assert.Empty(t, headers.Keys())
+	assert.Empty(t, recorder.Ended())
+}
@@ -0,0 +1,64 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/sosalejandro/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Producer starts the producer spans of the published messages
+type Producer[T any] struct {
+	cfg *config
+}
+
+// NewProducer creates a Producer with the given options
+func NewProducer[T any](opts ...Option) *Producer[T] {
+	return &Producer[T]{cfg: newConfig(opts)}
+}
+
+// Start starts a producer span for a message sent to the destination under the handler carried by ctx
+// and injects the context of the span into the headers
+// The context is injected as is when it doesn't carry a handler
+// finish ends the span, marking it as failed when the publish failed
+func (p *Producer[T]) Start(ctx context.Context, destination string, headers Headers, attrs ...attribute.KeyValue) (context.Context, func(err error)) {
+	h, ok := observability.HandlerFromContext[T](ctx)
+	if !ok {
+		p.cfg.textMapPropagator().Inject(ctx, headers)
+		return ctx, func(error) {}
+	}
+
+	ctx, end := h.WithContext(ctx).StartSpan(destination+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(spanAttributes(p.cfg, destination, "publish", attrs)...),
+	)
+	p.cfg.textMapPropagator().Inject(ctx, headers)
+	return ctx, finisher(ctx, end)
+}
+
+// spanAttributes returns the messaging attributes of the operation followed by the given attributes
+func spanAttributes(cfg *config, destination, operation string, attrs []attribute.KeyValue) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs)+3)
+	if cfg.system != "" {
+		kvs = append(kvs, observability.MessagingSystem(cfg.system))
+	}
+	kvs = append(kvs,
+		observability.MessagingDestinationName(destination),
+		observability.MessagingOperation(operation),
+	)
+	return append(kvs, attrs...)
+}
+
+// finisher returns a function ending the span of ctx, recording the error when set
+func finisher(ctx context.Context, end func(...trace.SpanEndOption)) func(error) {
+	return func(err error) {
+		if err != nil {
+			span := trace.SpanFromContext(ctx)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		end()
+	}
+}
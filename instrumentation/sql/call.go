@@ -0,0 +1,131 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sosalejandro/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// RowsAffectedKey is the number of rows affected by an exec
+	RowsAffectedKey = attribute.Key("db.rows_affected")
+	// ReturnedRowsKey is the number of rows read from a query
+	ReturnedRowsKey = attribute.Key("db.response.returned_rows")
+	// DurationKey is the duration of the call in logs
+	DurationKey = attribute.Key("db.duration")
+)
+
+// call is an instrumented call to the database
+type call[T any] struct {
+	cfg     *config[T]
+	handler observability.ObservabilityHandler[T]
+	ctx     context.Context
+	end     func(...trace.SpanEndOption)
+	start   time.Time
+	// statement is the redacted statement, empty for the calls without query
+	statement string
+	attrs     []attribute.KeyValue
+}
+
+// startCall starts a client span for the given operation under the handler of ctx
+// Returns a nil call when the context doesn't carry a handler
+func startCall[T any](ctx context.Context, cfg *config[T], operation, query string) (context.Context, *call[T]) {
+	h, ok := observability.HandlerFromContext[T](ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	attrs := make([]attribute.KeyValue, 0, 4)
+	if cfg.system != "" {
+		attrs = append(attrs, observability.DBSystem(cfg.system))
+	}
+	attrs = append(attrs, observability.DBOperation(operation))
+	var statement string
+	if query != "" {
+		statement = cfg.redact(query)
+		attrs = append(attrs, observability.DBStatement(statement))
+	}
+
+	c := &call[T]{cfg: cfg, handler: h.WithContext(ctx), start: time.Now(), statement: statement, attrs: attrs}
+	c.ctx, c.end = c.handler.StartSpan(operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return c.ctx, c
+}
+
+// setRows records the number of rows under the given key
+func (c *call[T]) setRows(key attribute.Key, rows int64) {
+	if c == nil {
+		return
+	}
+	kv := key.Int64(rows)
+	c.attrs = append(c.attrs, kv)
+	c.handler.SetAttributes(kv)
+}
+
+// finish logs the failed and slow calls and ends the span
+// driver.ErrSkip isn't a failure, database/sql retries the call another way
+func (c *call[T]) finish(err error) {
+	if c == nil {
+		return
+	}
+	defer c.end()
+
+	duration := time.Since(c.start)
+	switch {
+	case err != nil && !errors.Is(err, driver.ErrSkip):
+		trace.SpanFromContext(c.ctx).SetStatus(codes.Error, err.Error())
+		c.handler.LogErrorContext(c.logValues("database call failed", err, duration))
+	case c.cfg.slowThreshold > 0 && duration >= c.cfg.slowThreshold:
+		c.handler.LogInfoContext(c.logValues("slow database call", nil, duration))
+	}
+}
+
+// logValues creates the log values of the call with the redacted statement and the duration,
+// the other attributes are added when a converter is set
+func (c *call[T]) logValues(msg string, err error, duration time.Duration) observability.LogValues[T] {
+	b := c.handler.CreateLogBuilder().CreateLogValuesBuilder().
+		WithMsg(msg).
+		WithErr(err)
+	if c.statement != "" {
+		c.withValue(b, observability.DBStatement(c.statement), c.statement)
+	}
+	c.withValue(b, DurationKey.String(duration.String()), duration)
+	if c.cfg.convert != nil {
+		for _, kv := range c.attrs {
+			if kv.Key != observability.DBStatementKey {
+				b.WithAllLevelsValue(c.cfg.convert(kv))
+			}
+		}
+	}
+	return b.Build()
+}
+
+// withValue adds the value with the ValueConverter of the handler, or the attribute with the converter
+// of the options when the handler has none
+func (c *call[T]) withValue(b *observability.LogValuesBuilder[T], kv attribute.KeyValue, value any) {
+	if converted, ok := c.handler.ConvertValue(string(kv.Key), value); ok {
+		b.WithAllLevelsValue(converted)
+		return
+	}
+	if c.cfg.convert != nil {
+		b.WithAllLevelsValue(c.cfg.convert(kv))
+	}
+}
+
+// operation returns the first keyword of the query, e.g. SELECT
+func operation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,132 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// wrappedConn instruments the calls of a connection
+// Calls whose interface isn't implemented by the connection return driver.ErrSkip
+// so database/sql falls back to the implemented ones
+type wrappedConn[T any] struct {
+	conn driver.Conn
+	cfg  *config[T]
+}
+
+// Prepare prepares an instrumented statement without context
+func (c *wrappedConn[T]) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext prepares an instrumented statement in a PREPARE span
+func (c *wrappedConn[T]) PrepareContext(ctx context.Context, query string) (stmt driver.Stmt, err error) {
+	ctx, call := startCall(ctx, c.cfg, "PREPARE", query)
+	defer func() { call.finish(err) }()
+
+	if cp, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = cp.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &wrappedStmt[T]{stmt: stmt, query: query, cfg: c.cfg}, nil
+}
+
+// Close closes the connection
+func (c *wrappedConn[T]) Close() error {
+	return c.conn.Close()
+}
+
+// Begin starts a transaction without context
+func (c *wrappedConn[T]) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx starts an instrumented transaction in a BEGIN span
+func (c *wrappedConn[T]) BeginTx(ctx context.Context, opts driver.TxOptions) (tx driver.Tx, err error) {
+	spanCtx, call := startCall(ctx, c.cfg, "BEGIN", "")
+	defer func() { call.finish(err) }()
+
+	if cb, ok := c.conn.(driver.ConnBeginTx); ok {
+		tx, err = cb.BeginTx(spanCtx, opts)
+	} else if opts != (driver.TxOptions{}) {
+		err = errors.New("sql: driver does not support transaction options")
+	} else {
+		tx, err = c.conn.Begin()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &wrappedTx[T]{tx: tx, ctx: ctx, cfg: c.cfg}, nil
+}
+
+// ExecContext executes the query in a span recording the rows affected
+func (c *wrappedConn[T]) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (result driver.Result, err error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, call := startCall(ctx, c.cfg, operation(query), query)
+	defer func() { call.finish(err) }()
+
+	result, err = execer.ExecContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := result.RowsAffected(); err == nil {
+		call.setRows(RowsAffectedKey, rows)
+	}
+	return result, nil
+}
+
+// QueryContext runs the query in a span ended once the rows are closed
+func (c *wrappedConn[T]) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, call := startCall(ctx, c.cfg, operation(query), query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		call.finish(err)
+		return nil, err
+	}
+	return &wrappedRows[T]{rows: rows, call: call}, nil
+}
+
+// Ping pings the connection when supported
+func (c *wrappedConn[T]) Ping(ctx context.Context) error {
+	if pinger, ok := c.conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession resets the session when supported
+func (c *wrappedConn[T]) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid checks the connection when supported
+func (c *wrappedConn[T]) IsValid() bool {
+	if validator, ok := c.conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue checks the arguments when supported, database/sql uses the default converter otherwise
+func (c *wrappedConn[T]) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
@@ -0,0 +1,146 @@
+// Package sql instruments database/sql drivers with the ObservabilityHandler carried by the context
+//
+// Queries, execs, prepares and transactions start client spans under the handler stored in the context by StartSpan,
+// failed and slow calls are logged with redacted statements and row counts are recorded on the spans.
+// Calls without handler in the context aren't instrumented.
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Option configures the instrumentation
+type Option[T any] func(*config[T])
+
+// config contains the settings of the instrumentation
+type config[T any] struct {
+	// system is the db.system attribute, e.g. postgresql
+	system string
+	// slowThreshold is the duration from which calls are logged as slow, disabled when zero
+	slowThreshold time.Duration
+	// redact removes the sensitive values of the statements
+	redact func(string) string
+	// convert converts the attributes into log values, only the statement and the duration are logged when nil
+	convert func(attribute.KeyValue) T
+}
+
+// WithDBSystem sets the db.system attribute of the spans, e.g. postgresql
+func WithDBSystem[T any](system string) Option[T] {
+	return func(c *config[T]) {
+		c.system = system
+	}
+}
+
+// WithSlowQueryThreshold logs the calls lasting at least the given duration at the info level
+func WithSlowQueryThreshold[T any](threshold time.Duration) Option[T] {
+	return func(c *config[T]) {
+		c.slowThreshold = threshold
+	}
+}
+
+// WithStatementRedactor replaces RedactStatement to remove the sensitive values of the statements
+func WithStatementRedactor[T any](redact func(string) string) Option[T] {
+	return func(c *config[T]) {
+		c.redact = redact
+	}
+}
+
+// WithAttributeConverter converts the span attributes into log values, e.g. the zap adapter AttributeField
+// Logs only contain the message, the error, the redacted statement and the duration without converter,
+// the last two are converted by the ValueConverter of the handler
+func WithAttributeConverter[T any](convert func(attribute.KeyValue) T) Option[T] {
+	return func(c *config[T]) {
+		c.convert = convert
+	}
+}
+
+// newConfig creates the config with the given options
+func newConfig[T any](opts []Option[T]) *config[T] {
+	c := &config[T]{redact: RedactStatement}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Wrap instruments the connections opened by the given driver
+func Wrap[T any](d driver.Driver, opts ...Option[T]) driver.Driver {
+	return &wrappedDriver[T]{driver: d, cfg: newConfig(opts)}
+}
+
+// WrapConnector instruments the connections of the given connector, e.g. for sql.OpenDB
+func WrapConnector[T any](c driver.Connector, opts ...Option[T]) driver.Connector {
+	cfg := newConfig(opts)
+	return &wrappedConnector[T]{
+		connector: c,
+		driver:    &wrappedDriver[T]{driver: c.Driver(), cfg: cfg},
+		cfg:       cfg,
+	}
+}
+
+// wrappedDriver instruments the connections of a driver
+type wrappedDriver[T any] struct {
+	driver driver.Driver
+	cfg    *config[T]
+}
+
+// Open opens an instrumented connection
+func (d *wrappedDriver[T]) Open(name string) (driver.Conn, error) {
+	conn, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &wrappedConn[T]{conn: conn, cfg: d.cfg}, nil
+}
+
+// OpenConnector opens an instrumented connector
+// The name is opened on every Connect when the driver doesn't implement driver.DriverContext
+func (d *wrappedDriver[T]) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.driver.(driver.DriverContext); ok {
+		connector, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+		return &wrappedConnector[T]{connector: connector, driver: d, cfg: d.cfg}, nil
+	}
+	return &wrappedConnector[T]{connector: dsnConnector{name: name, driver: d.driver}, driver: d, cfg: d.cfg}, nil
+}
+
+// wrappedConnector instruments the connections of a connector
+type wrappedConnector[T any] struct {
+	connector driver.Connector
+	driver    *wrappedDriver[T]
+	cfg       *config[T]
+}
+
+// Connect opens an instrumented connection
+func (c *wrappedConnector[T]) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &wrappedConn[T]{conn: conn, cfg: c.cfg}, nil
+}
+
+// Driver returns the instrumented driver
+func (c *wrappedConnector[T]) Driver() driver.Driver {
+	return c.driver
+}
+
+// dsnConnector opens the name with drivers not implementing driver.DriverContext
+type dsnConnector struct {
+	name   string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
@@ -0,0 +1,268 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sosalejandro/observability"
+	"github.com/sosalejandro/observability/internal/spantest"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// logEntry is a log call captured by recordingLogger
+type logEntry struct {
+	level observability.Level
+	lv    observability.LogValues[string]
+}
+
+// recordingLogger is an ObservabilityLogger capturing every call
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) record(level observability.Level, lv observability.LogValues[string]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, lv: lv})
+}
+
+func (l *recordingLogger) all() []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]logEntry(nil), l.entries...)
+}
+
+func (l *recordingLogger) Enabled(observability.Level) bool { return true }
+
+func (l *recordingLogger) LogInfo(lv observability.LogValues[string]) {
+	l.record(observability.InfoLevel, lv)
+}
+
+func (l *recordingLogger) LogError(lv observability.LogValues[string]) {
+	l.record(observability.ErrorLevel, lv)
+}
+
+func (l *recordingLogger) LogDebug(lv observability.LogValues[string]) {
+	l.record(observability.DebugLevel, lv)
+}
+
+func (l *recordingLogger) LogInfoContext(_ context.Context, lv observability.LogValues[string]) {
+	l.record(observability.InfoLevel, lv)
+}
+
+func (l *recordingLogger) LogErrorContext(_ context.Context, lv observability.LogValues[string]) {
+	l.record(observability.ErrorLevel, lv)
+}
+
+func (l *recordingLogger) LogDebugContext(_ context.Context, lv observability.LogValues[string]) {
+	l.record(observability.DebugLevel, lv)
+}
+
+// convert renders the attributes as key=value
+func convert(kv attribute.KeyValue) string {
+	return string(kv.Key) + "=" + kv.Value.Emit()
+}
+
+// setup opens an instrumented database and starts a request span in a handler carried by the returned context
+func setup(t *testing.T, connector fakeConnector, opts ...Option[string]) (context.Context, *dbsql.DB, *recordingLogger, *spantest.Recorder) {
+	recorder := spantest.NewRecorder()
+	logger := &recordingLogger{}
+	h := observability.NewObservabilityHandler[string](context.Background(), "orders", logger, observability.WithTracerProvider[string](recorder))
+	ctx, end := h.StartSpan("GET /orders")
+	t.Cleanup(func() { end() })
+
+	opts = append([]Option[string]{WithDBSystem[string]("postgresql"), WithAttributeConverter[string](convert)}, opts...)
+	db := dbsql.OpenDB(WrapConnector[string](connector, opts...))
+	t.Cleanup(func() { _ = db.Close() })
+	return ctx, db, logger, recorder
+}
+
+// spanAttributes returns the attributes of the span as a map
+func spanAttributes(span *spantest.Span) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+// Test that queries run in client spans children of the request span recording the rows read
+func TestWrap_Query(t *testing.T) {
+	ctx, db, logger, recorder := setup(t, fakeConnector{})
+	request := trace.SpanContextFromContext(ctx)
+
+	rows, err := db.QueryContext(ctx, "SELECT id FROM orders WHERE customer = 'john' AND total > 100")
+	assert.NoError(t, err)
+	for rows.Next() {
+	}
+	assert.NoError(t, rows.Close())
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "SELECT", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Equal(t, request.SpanID(), spans[0].Parent().SpanID())
+
+	attrs := spanAttributes(spans[0])
+	assert.Equal(t, "postgresql", attrs[observability.DBSystemKey].AsString())
+	assert.Equal(t, "SELECT", attrs[observability.DBOperationKey].AsString())
+	assert.Equal(t, "SELECT id FROM orders WHERE customer = ? AND total > ?", attrs[observability.DBStatementKey].AsString())
+	assert.Equal(t, int64(3), attrs[ReturnedRowsKey].AsInt64())
+	assert.Empty(t, logger.all())
+}
+
+// Test that the result sets and the column types of the wrapped rows are forwarded
+func TestWrap_QueryResultSetsAndColumnTypes(t *testing.T) {
+	ctx, db, _, recorder := setup(t, fakeConnector{})
+
+	rows, err := db.QueryContext(ctx, "CALL orders_summary()")
+	assert.NoError(t, err)
+	types, err := rows.ColumnTypes()
+	assert.NoError(t, err)
+	assert.Equal(t, "BIGINT", types[0].DatabaseTypeName())
+	assert.Equal(t, reflect.TypeOf(int64(0)), types[0].ScanType())
+	nullable, ok := types[0].Nullable()
+	assert.True(t, ok)
+	assert.False(t, nullable)
+
+	sets := 0
+	for {
+		for rows.Next() {
+		}
+		sets++
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	assert.NoError(t, rows.Err())
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, 2, sets)
+	assert.Equal(t, int64(2), spanAttributes(recorder.Ended()[0])[ReturnedRowsKey].AsInt64())
+}
+
+// Test that failed calls are logged with the redacted statement and mark the span as failed
+func TestWrap_ExecError(t *testing.T) {
+	ctx, db, logger, recorder := setup(t, fakeConnector{})
+
+	_, err := db.ExecContext(ctx, "UPDATE fail SET total = 10 WHERE id = $1", 42)
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+	entries := logger.all()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, observability.ErrorLevel, entries[0].level)
+	assert.EqualError(t, entries[0].lv.Err(), "relation does not exist")
+	assert.Contains(t, entries[0].lv.Values(observability.ErrorLevel), "db.statement=UPDATE fail SET total = ? WHERE id = $1")
+}
+
+// Test that the failed calls are logged with the redacted statement and the duration by default
+// through the ValueConverter of the handler
+func TestWrap_DefaultOptions(t *testing.T) {
+	logger := &recordingLogger{}
+	h := observability.NewObservabilityHandler[string](context.Background(), "orders", logger,
+		observability.WithTracerProvider[string](spantest.NewRecorder()),
+		observability.WithValueConverter[string](func(key string, value any) string { return fmt.Sprintf("%s=%v", key, value) }))
+	ctx, end := h.StartSpan("GET /orders")
+	defer end()
+	db := dbsql.OpenDB(WrapConnector[string](fakeConnector{}))
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, "UPDATE fail SET total = 10 WHERE id = $1", 42)
+	assert.Error(t, err)
+
+	entries := logger.all()
+	assert.Len(t, entries, 1)
+	values := entries[0].lv.Values(observability.ErrorLevel)
+	assert.Len(t, values, 2)
+	assert.Equal(t, "db.statement=UPDATE fail SET total = ? WHERE id = $1", values[0])
+	assert.True(t, strings.HasPrefix(values[1], "db.duration="))
+}
+
+// Test that execs record the rows affected and slow calls are logged at the info level
+func TestWrap_ExecSlow(t *testing.T) {
+	ctx, db, logger, recorder := setup(t, fakeConnector{}, WithSlowQueryThreshold[string](time.Nanosecond))
+
+	result, err := db.ExecContext(ctx, "DELETE FROM orders")
+	assert.NoError(t, err)
+	affected, _ := result.RowsAffected()
+	assert.Equal(t, int64(2), affected)
+
+	assert.Equal(t, int64(2), spanAttributes(recorder.Ended()[0])[RowsAffectedKey].AsInt64())
+	entries := logger.all()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, observability.InfoLevel, entries[0].level)
+	assert.Equal(t, "slow database call", entries[0].lv.Msg())
+	assert.Contains(t, entries[0].lv.Values(observability.InfoLevel), "db.rows_affected=2")
+}
+
+// Test that transactions and prepared statements run in their own spans
+func TestWrap_TransactionAndPrepare(t *testing.T) {
+	ctx, db, _, recorder := setup(t, fakeConnector{legacy: true})
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+	// Assert the legacy connection falls back to prepared statements
+	_, err = tx.ExecContext(ctx, "INSERT INTO orders VALUES (1)")
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+	assert.Equal(t, []string{"BEGIN", "PREPARE", "INSERT", "COMMIT"}, names)
+}
+
+// Test that calls without handler in the context aren't instrumented
+func TestWrap_WithoutHandler(t *testing.T) {
+	_, db, logger, recorder := setup(t, fakeConnector{})
+
+	_, err := db.ExecContext(context.Background(), "UPDATE fail SET total = 10")
+	assert.Error(t, err)
+	rows, err := db.QueryContext(context.Background(), "SELECT id FROM orders")
+	assert.NoError(t, err)
+	assert.NoError(t, rows.Close())
+
+	assert.Empty(t, recorder.Ended())
+	assert.Empty(t, logger.all())
+}
+
+// Test that Wrap instruments the connections of drivers without connector
+func TestWrap_Driver(t *testing.T) {
+	ctx, _, _, recorder := setup(t, fakeConnector{})
+
+	connector, err := Wrap[string](fakeDriver{}).(driver.DriverContext).OpenConnector("orders")
+	assert.NoError(t, err)
+	db := dbsql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, "DELETE FROM orders")
+	assert.NoError(t, err)
+	assert.Len(t, recorder.Ended(), 1)
+}
+
+// Test that RedactStatement replaces the literals and keeps the placeholders
+func TestRedactStatement(t *testing.T) {
+	tests := map[string]string{
+		"SELECT * FROM users WHERE id = $1 AND name = 'john'":            "SELECT * FROM users WHERE id = $1 AND name = ?",
+		"SELECT * FROM t2 WHERE a IN (1, 2.5) AND b = 'it''s' AND c = ?": "SELECT * FROM t2 WHERE a IN (?, ?) AND b = ? AND c = ?",
+		"UPDATE users SET name = :name WHERE id = @id":                   "UPDATE users SET name = :name WHERE id = @id",
+	}
+	for query, want := range tests {
+		assert.Equal(t, want, RedactStatement(query))
+	}
+}
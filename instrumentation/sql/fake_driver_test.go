@@ -0,0 +1,153 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fakeConnector opens fakeConn connections
+type fakeConnector struct {
+	// legacy opens connections without the context interfaces
+	legacy bool
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	if c.legacy {
+		return &legacyConn{}, nil
+	}
+	return &fakeConn{}, nil
+}
+
+func (c fakeConnector) Driver() driver.Driver { return fakeDriver{} }
+
+// fakeDriver opens fakeConn connections
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{}, nil }
+
+// legacyConn only implements driver.Conn
+// Queries containing fail return an error, CALL returns 2 result sets, SELECT returns 3 rows and other queries affect 2 rows
+type legacyConn struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+func (c *legacyConn) Prepare(query string) (driver.Stmt, error) {
+	if strings.Contains(query, "fail prepare") {
+		return nil, errors.New("prepare failed")
+	}
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *legacyConn) Close() error { return nil }
+
+func (c *legacyConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+func (c *legacyConn) run(query string) (driver.Rows, driver.Result, error) {
+	c.mu.Lock()
+	c.queries = append(c.queries, query)
+	c.mu.Unlock()
+
+	if strings.Contains(query, "fail") {
+		return nil, nil, errors.New("relation does not exist")
+	}
+	if strings.HasPrefix(query, "CALL") {
+		return &multiRows{fakeRows: fakeRows{left: 1}, sets: 1}, nil, nil
+	}
+	if strings.HasPrefix(query, "SELECT") {
+		return &fakeRows{left: 3}, nil, nil
+	}
+	return nil, driver.RowsAffected(2), nil
+}
+
+// fakeConn implements the context interfaces of the connections
+type fakeConn struct {
+	legacyConn
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	_, result, err := c.run(query)
+	return result, err
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	rows, _, err := c.run(query)
+	return rows, err
+}
+
+// fakeStmt runs its query on the connection
+type fakeStmt struct {
+	conn  *legacyConn
+	query string
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	_, result, err := s.conn.run(s.query)
+	return result, err
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	rows, _, err := s.conn.run(s.query)
+	return rows, err
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error { return nil }
+
+func (fakeTx) Rollback() error { return nil }
+
+// fakeRows returns rows with a single id column
+type fakeRows struct {
+	left int
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.left == 0 {
+		return io.EOF
+	}
+	dest[0] = int64(r.left)
+	r.left--
+	return nil
+}
+
+// multiRows returns several result sets of a single row with typed columns
+type multiRows struct {
+	fakeRows
+	// sets is the number of result sets left after the current one
+	sets int
+}
+
+func (r *multiRows) HasNextResultSet() bool { return r.sets > 0 }
+
+func (r *multiRows) NextResultSet() error {
+	if r.sets == 0 {
+		return io.EOF
+	}
+	r.sets--
+	r.left = 1
+	return nil
+}
+
+func (r *multiRows) ColumnTypeScanType(int) reflect.Type { return reflect.TypeOf(int64(0)) }
+
+func (r *multiRows) ColumnTypeDatabaseTypeName(int) string { return "BIGINT" }
+
+func (r *multiRows) ColumnTypeNullable(int) (bool, bool) { return false, true }
@@ -0,0 +1,18 @@
+package sql
+
+import "regexp"
+
+var (
+	// stringLiterals matches the quoted strings of a statement, including escaped quotes
+	stringLiterals = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// numberLiterals matches the numbers of a statement outside identifiers and placeholders such as $1
+	numberLiterals = regexp.MustCompile(`(^|[^\w$:@?.])\d+(?:\.\d+)?\b`)
+)
+
+// RedactStatement replaces the string and number literals of the statement with ?
+// Placeholders are kept as is, e.g. SELECT * FROM users WHERE id = $1 AND name = 'john' becomes
+// SELECT * FROM users WHERE id = $1 AND name = ?
+func RedactStatement(query string) string {
+	query = stringLiterals.ReplaceAllString(query, "?")
+	return numberLiterals.ReplaceAllString(query, "${1}?")
+}
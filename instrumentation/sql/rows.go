@@ -0,0 +1,109 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+)
+
+// wrappedRows counts the rows read and ends the span of the query once closed
+// The optional interfaces of the rows are forwarded, their methods return what database/sql assumes
+// when the wrapped rows don't implement them
+type wrappedRows[T any] struct {
+	rows  driver.Rows
+	call  *call[T]
+	count int64
+	// err is the first error returned by Next other than io.EOF
+	err error
+}
+
+// Columns returns the names of the columns
+func (r *wrappedRows[T]) Columns() []string {
+	return r.rows.Columns()
+}
+
+// Next reads the next row
+func (r *wrappedRows[T]) Next(dest []driver.Value) error {
+	err := r.rows.Next(dest)
+	switch {
+	case err == nil:
+		r.count++
+	case !errors.Is(err, io.EOF) && r.err == nil:
+		r.err = err
+	}
+	return err
+}
+
+// Close closes the rows and ends the span with the number of rows read
+func (r *wrappedRows[T]) Close() error {
+	err := r.rows.Close()
+	if r.call == nil {
+		return err
+	}
+
+	r.call.setRows(ReturnedRowsKey, r.count)
+	if r.err != nil {
+		r.call.finish(r.err)
+	} else {
+		r.call.finish(err)
+	}
+	r.call = nil
+	return err
+}
+
+// HasNextResultSet reports whether there is another result set, false when the rows don't support several ones
+func (r *wrappedRows[T]) HasNextResultSet() bool {
+	if rows, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return rows.HasNextResultSet()
+	}
+	return false
+}
+
+// NextResultSet advances to the next result set, the rows read from every set are counted
+func (r *wrappedRows[T]) NextResultSet() error {
+	if rows, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return rows.NextResultSet()
+	}
+	return io.EOF
+}
+
+// ColumnTypeScanType returns the type the column scans into, any when the rows don't report it
+func (r *wrappedRows[T]) ColumnTypeScanType(index int) reflect.Type {
+	if rows, ok := r.rows.(driver.RowsColumnTypeScanType); ok {
+		return rows.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf(new(any)).Elem()
+}
+
+// ColumnTypeDatabaseTypeName returns the database type of the column, empty when the rows don't report it
+func (r *wrappedRows[T]) ColumnTypeDatabaseTypeName(index int) string {
+	if rows, ok := r.rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return rows.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+// ColumnTypeLength returns the length of the variable length columns
+func (r *wrappedRows[T]) ColumnTypeLength(index int) (int64, bool) {
+	if rows, ok := r.rows.(driver.RowsColumnTypeLength); ok {
+		return rows.ColumnTypeLength(index)
+	}
+	return 0, false
+}
+
+// ColumnTypeNullable reports whether the column may be null
+func (r *wrappedRows[T]) ColumnTypeNullable(index int) (bool, bool) {
+	if rows, ok := r.rows.(driver.RowsColumnTypeNullable); ok {
+		return rows.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+// ColumnTypePrecisionScale returns the precision and scale of the decimal columns
+func (r *wrappedRows[T]) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if rows, ok := r.rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return rows.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
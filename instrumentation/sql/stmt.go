@@ -0,0 +1,111 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// wrappedStmt instruments the calls of a prepared statement
+type wrappedStmt[T any] struct {
+	stmt  driver.Stmt
+	query string
+	cfg   *config[T]
+}
+
+// Close closes the statement
+func (s *wrappedStmt[T]) Close() error {
+	return s.stmt.Close()
+}
+
+// NumInput returns the number of placeholders of the statement
+func (s *wrappedStmt[T]) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+// Exec executes the statement without context
+func (s *wrappedStmt[T]) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+// Query runs the statement without context
+func (s *wrappedStmt[T]) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+// ExecContext executes the statement in a span recording the rows affected
+func (s *wrappedStmt[T]) ExecContext(ctx context.Context, args []driver.NamedValue) (result driver.Result, err error) {
+	ctx, call := startCall(ctx, s.cfg, operation(s.query), s.query)
+	defer func() { call.finish(err) }()
+
+	if execer, ok := s.stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = driverValues(args); err == nil {
+			result, err = s.stmt.Exec(values)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := result.RowsAffected(); err == nil {
+		call.setRows(RowsAffectedKey, rows)
+	}
+	return result, nil
+}
+
+// QueryContext runs the statement in a span ended once the rows are closed
+func (s *wrappedStmt[T]) QueryContext(ctx context.Context, args []driver.NamedValue) (rows driver.Rows, err error) {
+	ctx, call := startCall(ctx, s.cfg, operation(s.query), s.query)
+
+	if queryer, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = driverValues(args); err == nil {
+			rows, err = s.stmt.Query(values)
+		}
+	}
+	if err != nil {
+		call.finish(err)
+		return nil, err
+	}
+	return &wrappedRows[T]{rows: rows, call: call}, nil
+}
+
+// CheckNamedValue checks the arguments with the checker or the column converter of the statement
+// database/sql uses the default converter otherwise
+func (s *wrappedStmt[T]) CheckNamedValue(nv *driver.NamedValue) (err error) {
+	switch stmt := s.stmt.(type) {
+	case driver.NamedValueChecker:
+		return stmt.CheckNamedValue(nv)
+	case driver.ColumnConverter:
+		nv.Value, err = stmt.ColumnConverter(nv.Ordinal - 1).ConvertValue(nv.Value)
+		return err
+	default:
+		return driver.ErrSkip
+	}
+}
+
+// namedValues converts the ordinal values into named values
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+// driverValues converts the named values into ordinal values
+// Returns an error if a value is named, the statement doesn't support them
+func driverValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("sql: driver does not support the use of Named Parameters")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,28 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// wrappedTx instruments the end of a transaction
+type wrappedTx[T any] struct {
+	tx driver.Tx
+	// ctx is the context the transaction was started with
+	ctx context.Context
+	cfg *config[T]
+}
+
+// Commit commits the transaction in a COMMIT span
+func (t *wrappedTx[T]) Commit() (err error) {
+	_, call := startCall(t.ctx, t.cfg, "COMMIT", "")
+	defer func() { call.finish(err) }()
+	return t.tx.Commit()
+}
+
+// Rollback rolls back the transaction in a ROLLBACK span
+func (t *wrappedTx[T]) Rollback() (err error) {
+	_, call := startCall(t.ctx, t.cfg, "ROLLBACK", "")
+	defer func() { call.finish(err) }()
+	return t.tx.Rollback()
+}
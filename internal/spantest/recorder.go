@@ -0,0 +1,190 @@
+// Package spantest provides a TracerProvider recording the spans for the tests of the instrumentations,
+// so the module doesn't depend on the OpenTelemetry SDK
+package spantest
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Status is the status set on a span
+type Status struct {
+	Code        codes.Code
+	Description string
+}
+
+// Recorder is a TracerProvider recording every span once ended
+type Recorder struct {
+	mu    sync.Mutex
+	ended []*Span
+}
+
+// NewRecorder creates a Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Tracer returns a tracer starting sampled spans recorded by the Recorder
+func (r *Recorder) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return tracer{recorder: r}
+}
+
+// Ended returns the ended spans in the order they ended
+func (r *Recorder) Ended() []*Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Span(nil), r.ended...)
+}
+
+type tracer struct {
+	recorder *Recorder
+}
+
+// Start starts a span child of the span of the context, or a new trace for root spans
+func (t tracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	var parent trace.SpanContext
+	if !config.NewRoot() {
+		parent = trace.SpanContextFromContext(ctx)
+	}
+
+	traceID := parent.TraceID()
+	if !parent.IsValid() {
+		_, _ = rand.Read(traceID[:])
+	}
+	var spanID trace.SpanID
+	_, _ = rand.Read(spanID[:])
+
+	span := &Span{
+		recorder: t.recorder,
+		name:     name,
+		kind:     config.SpanKind(),
+		parent:   parent,
+		attrs:    config.Attributes(),
+		links:    config.Links(),
+		sc: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}),
+	}
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// Span is a span recorded by a Recorder
+type Span struct {
+	recorder *Recorder
+	sc       trace.SpanContext
+	parent   trace.SpanContext
+	kind     trace.SpanKind
+	links    []trace.Link
+
+	mu     sync.Mutex
+	name   string
+	attrs  []attribute.KeyValue
+	status Status
+	ended  bool
+}
+
+// Name returns the name of the span
+func (s *Span) Name() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.name
+}
+
+// SpanKind returns the kind of the span
+func (s *Span) SpanKind() trace.SpanKind {
+	return s.kind
+}
+
+// Parent returns the span context of the parent, invalid for root spans
+func (s *Span) Parent() trace.SpanContext {
+	return s.parent
+}
+
+// Links returns the links given when the span started
+func (s *Span) Links() []trace.Link {
+	return s.links
+}
+
+// Attributes returns the attributes of the span
+func (s *Span) Attributes() []attribute.KeyValue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]attribute.KeyValue(nil), s.attrs...)
+}
+
+// Status returns the status of the span
+func (s *Span) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// End records the span, the calls after the first one are ignored
+func (s *Span) End(...trace.SpanEndOption) {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.mu.Unlock()
+
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.ended = append(s.recorder.ended, s)
+}
+
+// AddEvent ignores the event
+func (s *Span) AddEvent(string, ...trace.EventOption) {}
+
+// IsRecording returns true until the span ended
+func (s *Span) IsRecording() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.ended
+}
+
+// RecordError ignores the error, the instrumentations set it in the status
+func (s *Span) RecordError(error, ...trace.EventOption) {}
+
+// SpanContext returns the span context of the span
+func (s *Span) SpanContext() trace.SpanContext {
+	return s.sc
+}
+
+// SetStatus sets the status of the span, the description is kept for errors only
+func (s *Span) SetStatus(code codes.Code, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if code != codes.Error {
+		description = ""
+	}
+	s.status = Status{Code: code, Description: description}
+}
+
+// SetName renames the span
+func (s *Span) SetName(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.name = name
+}
+
+// SetAttributes adds the attributes to the span
+func (s *Span) SetAttributes(kv ...attribute.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, kv...)
+}
+
+// TracerProvider returns the Recorder of the span
+func (s *Span) TracerProvider() trace.TracerProvider {
+	return s.recorder
+}
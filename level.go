@@ -0,0 +1,87 @@
+package observability
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Level is the severity of a log entry.
+//
+// The built-in levels follow the spacing used by log/slog so custom levels
+// can be declared between them, e.g.:
+//
+//	const TraceLevel observability.Level = -8
+//	const NoticeLevel observability.Level = 2
+//	const AuditLevel observability.Level = 12
+type Level int
+
+const (
+	// DebugLevel is used for verbose diagnostic values
+	DebugLevel Level = -4
+	// InfoLevel is used for general operational values
+	InfoLevel Level = 0
+	// WarnLevel is used for values attached to recoverable issues
+	// It has no Log* method, the loggers without Log receive it at the info level, refer to LevelLogger
+	WarnLevel Level = 4
+	// ErrorLevel is used for values attached to failures
+	ErrorLevel Level = 8
+)
+
+// String returns the name of the level
+// Custom levels are rendered as LEVEL(n)
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	default:
+		return "LEVEL(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+// MarshalText marshals the level into its name
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText unmarshals a level name, refer to ParseLevel
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// ParseLevel parses a level name
+// Accepts the built-in names (case-insensitive), LEVEL(n) and plain integers for custom levels
+func ParseLevel(name string) (Level, error) {
+	name = strings.TrimSpace(name)
+	switch strings.ToLower(name) {
+	case "debug":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	}
+
+	raw := name
+	if upper := strings.ToUpper(name); strings.HasPrefix(upper, "LEVEL(") && strings.HasSuffix(upper, ")") {
+		raw = name[len("LEVEL(") : len(name)-1]
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("unknown level %q", name)
+	}
+	return Level(value), nil
+}
@@ -0,0 +1,123 @@
+package observability
+
+import "sync"
+
+// LevelController holds the minimum levels enabled at runtime
+//
+// The global level can be overridden per service name and per span name,
+// span overrides take precedence over service overrides.
+// It's safe for concurrent use and can be exposed through its http.Handler.
+type LevelController struct {
+	mu sync.RWMutex
+	// level is the global minimum level
+	level Level
+	// services contains the minimum level overrides per service name
+	services map[string]Level
+	// spans contains the minimum level overrides per span name
+	spans map[string]Level
+	// listeners are notified with the lowest enabled level after every change
+	listeners []func(Level)
+}
+
+// NewLevelController creates a new LevelController with the given global level
+func NewLevelController(level Level) *LevelController {
+	return &LevelController{
+		level:    level,
+		services: make(map[string]Level),
+		spans:    make(map[string]Level),
+	}
+}
+
+// Level returns the global minimum level
+func (c *LevelController) Level() Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.level
+}
+
+// SetLevel sets the global minimum level
+func (c *LevelController) SetLevel(level Level) {
+	c.update(func() { c.level = level })
+}
+
+// SetServiceLevel overrides the minimum level for the given service name
+func (c *LevelController) SetServiceLevel(service string, level Level) {
+	c.update(func() { c.services[service] = level })
+}
+
+// ClearServiceLevel removes the minimum level override for the given service name
+func (c *LevelController) ClearServiceLevel(service string) {
+	c.update(func() { delete(c.services, service) })
+}
+
+// SetSpanLevel overrides the minimum level for the given span name
+func (c *LevelController) SetSpanLevel(span string, level Level) {
+	c.update(func() { c.spans[span] = level })
+}
+
+// ClearSpanLevel removes the minimum level override for the given span name
+func (c *LevelController) ClearSpanLevel(span string) {
+	c.update(func() { delete(c.spans, span) })
+}
+
+// EffectiveLevel returns the minimum level for the given service and span names
+func (c *LevelController) EffectiveLevel(service, span string) Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if level, ok := c.spans[span]; ok {
+		return level
+	}
+	if level, ok := c.services[service]; ok {
+		return level
+	}
+	return c.level
+}
+
+// Enabled checks if the given level is enabled for the given service and span names
+func (c *LevelController) Enabled(level Level, service, span string) bool {
+	return level >= c.EffectiveLevel(service, span)
+}
+
+// MinLevel returns the lowest level enabled by the global level or any override
+// Backends filtering by level on their own should be set to this level
+func (c *LevelController) MinLevel() Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.minLevel()
+}
+
+// OnChange registers a function called with the lowest enabled level after every change
+func (c *LevelController) OnChange(fn func(Level)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, fn)
+}
+
+// update applies the given change and notifies the listeners
+func (c *LevelController) update(change func()) {
+	c.mu.Lock()
+	change()
+	minLevel := c.minLevel()
+	listeners := c.listeners
+	c.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(minLevel)
+	}
+}
+
+// minLevel returns the lowest enabled level, requires the lock to be held
+func (c *LevelController) minLevel() Level {
+	minLevel := c.level
+	for _, level := range c.services {
+		if level < minLevel {
+			minLevel = level
+		}
+	}
+	for _, level := range c.spans {
+		if level < minLevel {
+			minLevel = level
+		}
+	}
+	return minLevel
+}
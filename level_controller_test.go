@@ -0,0 +1,46 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that span overrides take precedence over service overrides and the global level
+func TestLevelController_EffectiveLevel(t *testing.T) {
+	c := NewLevelController(InfoLevel)
+	c.SetServiceLevel("checkout", ErrorLevel)
+	c.SetSpanLevel("GET /orders", DebugLevel)
+
+	assert.Equal(t, InfoLevel, c.EffectiveLevel("payments", "GET /cart"))
+	assert.Equal(t, ErrorLevel, c.EffectiveLevel("checkout", "GET /cart"))
+	assert.Equal(t, DebugLevel, c.EffectiveLevel("checkout", "GET /orders"))
+
+	c.ClearSpanLevel("GET /orders")
+	c.ClearServiceLevel("checkout")
+	assert.Equal(t, InfoLevel, c.EffectiveLevel("checkout", "GET /orders"))
+}
+
+// Test that Enabled compares the level against the effective level
+func TestLevelController_Enabled(t *testing.T) {
+	c := NewLevelController(InfoLevel)
+	assert.False(t, c.Enabled(DebugLevel, "checkout", ""))
+	assert.True(t, c.Enabled(ErrorLevel, "checkout", ""))
+
+	c.SetLevel(DebugLevel)
+	assert.True(t, c.Enabled(DebugLevel, "checkout", ""))
+}
+
+// Test that OnChange listeners receive the lowest enabled level
+func TestLevelController_OnChange(t *testing.T) {
+	c := NewLevelController(ErrorLevel)
+	var got []Level
+	c.OnChange(func(level Level) { got = append(got, level) })
+
+	c.SetSpanLevel("GET /orders", DebugLevel)
+	c.SetLevel(InfoLevel)
+	c.ClearSpanLevel("GET /orders")
+
+	assert.Equal(t, []Level{DebugLevel, DebugLevel, InfoLevel}, got)
+	assert.Equal(t, InfoLevel, c.MinLevel())
+}
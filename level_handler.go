@@ -0,0 +1,110 @@
+package observability
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// levelState is the representation of the LevelController served over HTTP
+type levelState struct {
+	Level    Level            `json:"level"`
+	Services map[string]Level `json:"services"`
+	Spans    map[string]Level `json:"spans"`
+}
+
+// levelRequest is the body accepted by PUT requests
+//
+// Without service or span the global level is set,
+// with service or span an empty level removes the override.
+type levelRequest struct {
+	Level   string `json:"level"`
+	Service string `json:"service,omitempty"`
+	Span    string `json:"span,omitempty"`
+}
+
+// ServeHTTP exposes the LevelController as an admin endpoint
+//
+// GET returns the global level and the overrides.
+// PUT receives {"level": "debug"} to set the global level,
+// {"level": "debug", "service": "name"} or {"level": "debug", "span": "name"} to set an override
+// and {"level": "", "service": "name"} or {"level": "", "span": "name"} to remove it.
+func (c *LevelController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if err := c.apply(r); err != nil {
+			writeLevelJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		writeLevelJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	writeLevelJSON(w, http.StatusOK, c.state())
+}
+
+// apply applies the change described by the request body
+func (c *LevelController) apply(r *http.Request) error {
+	var req levelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.New("invalid body: " + err.Error())
+	}
+	if req.Service != "" && req.Span != "" {
+		return errors.New("service and span can't be set together")
+	}
+
+	if req.Level == "" {
+		switch {
+		case req.Service != "":
+			c.ClearServiceLevel(req.Service)
+		case req.Span != "":
+			c.ClearSpanLevel(req.Span)
+		default:
+			return errors.New("level is required")
+		}
+		return nil
+	}
+
+	level, err := ParseLevel(req.Level)
+	if err != nil {
+		return err
+	}
+	switch {
+	case req.Service != "":
+		c.SetServiceLevel(req.Service, level)
+	case req.Span != "":
+		c.SetSpanLevel(req.Span, level)
+	default:
+		c.SetLevel(level)
+	}
+	return nil
+}
+
+// state returns a copy of the levels held by the controller
+func (c *LevelController) state() levelState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	state := levelState{
+		Level:    c.level,
+		Services: make(map[string]Level, len(c.services)),
+		Spans:    make(map[string]Level, len(c.spans)),
+	}
+	for service, level := range c.services {
+		state.Services[service] = level
+	}
+	for span, level := range c.spans {
+		state.Spans[span] = level
+	}
+	return state
+}
+
+// writeLevelJSON writes the given value as a JSON response
+func writeLevelJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
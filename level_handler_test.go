@@ -0,0 +1,51 @@
+package observability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveLevel(c *LevelController, method, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(method, "/log/level", strings.NewReader(body)))
+	return rec
+}
+
+// Test that GET returns the global level and the overrides
+func TestLevelController_ServeHTTP_Get(t *testing.T) {
+	c := NewLevelController(InfoLevel)
+	c.SetServiceLevel("checkout", DebugLevel)
+
+	rec := serveLevel(c, http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"info","services":{"checkout":"debug"},"spans":{}}`, rec.Body.String())
+}
+
+// Test that PUT sets the global level and the overrides
+func TestLevelController_ServeHTTP_Put(t *testing.T) {
+	c := NewLevelController(InfoLevel)
+
+	assert.Equal(t, http.StatusOK, serveLevel(c, http.MethodPut, `{"level":"debug"}`).Code)
+	assert.Equal(t, DebugLevel, c.Level())
+
+	assert.Equal(t, http.StatusOK, serveLevel(c, http.MethodPut, `{"level":"error","span":"GET /orders"}`).Code)
+	assert.Equal(t, ErrorLevel, c.EffectiveLevel("", "GET /orders"))
+
+	assert.Equal(t, http.StatusOK, serveLevel(c, http.MethodPut, `{"level":"","span":"GET /orders"}`).Code)
+	assert.Equal(t, DebugLevel, c.EffectiveLevel("", "GET /orders"))
+}
+
+// Test that invalid requests are rejected without changing the levels
+func TestLevelController_ServeHTTP_Invalid(t *testing.T) {
+	c := NewLevelController(InfoLevel)
+
+	assert.Equal(t, http.StatusBadRequest, serveLevel(c, http.MethodPut, `{"level":"verbose"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serveLevel(c, http.MethodPut, `{"level":"debug","service":"a","span":"b"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serveLevel(c, http.MethodPut, `{}`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serveLevel(c, http.MethodPost, `{"level":"debug"}`).Code)
+	assert.Equal(t, InfoLevel, c.Level())
+}
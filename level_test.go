@@ -0,0 +1,46 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that String returns the name of the built-in and custom levels
+func TestLevel_String(t *testing.T) {
+	assert.Equal(t, "debug", DebugLevel.String())
+	assert.Equal(t, "info", InfoLevel.String())
+	assert.Equal(t, "warn", WarnLevel.String())
+	assert.Equal(t, "error", ErrorLevel.String())
+	assert.Equal(t, "LEVEL(12)", Level(12).String())
+}
+
+// Test that ParseLevel parses built-in names and custom levels
+func TestParseLevel(t *testing.T) {
+	for name, want := range map[string]Level{
+		"debug":     DebugLevel,
+		"INFO":      InfoLevel,
+		" error ":   ErrorLevel,
+		"warn":      WarnLevel,
+		"Warning":   WarnLevel,
+		"LEVEL(12)": Level(12),
+		"-8":        Level(-8),
+	} {
+		level, err := ParseLevel(name)
+		assert.NoError(t, err)
+		assert.Equal(t, want, level)
+	}
+
+	_, err := ParseLevel("verbose")
+	assert.Error(t, err)
+}
+
+// Test that a level round trips through its text representation
+func TestLevel_Text(t *testing.T) {
+	text, err := Level(12).MarshalText()
+	assert.NoError(t, err)
+
+	var level Level
+	assert.NoError(t, level.UnmarshalText(text))
+	assert.Equal(t, Level(12), level)
+}
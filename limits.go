@@ -0,0 +1,172 @@
+package observability
+
+import (
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// TruncationMarker is appended to the truncated messages
+const TruncationMarker = "...[truncated]"
+
+// Limits bounds the size of the logs and span events, a zero limit disables it
+type Limits[T any] struct {
+	// MaxMessageLength is the maximum length of the messages in bytes, TruncationMarker excluded
+	MaxMessageLength int
+	// MaxValuesPerLevel is the maximum number of values logged at a level, values attached to every level included
+	MaxValuesPerLevel int
+	// MaxValueSize is the maximum size of a value, applied by TruncateValue
+	MaxValueSize int
+	// MaxSpanEvents is the maximum number of events added to a span by the handler
+	MaxSpanEvents int
+	// TruncateValue truncates the value to the given size and reports whether it was truncated
+	// Provided by adapters, values aren't truncated when nil
+	TruncateValue func(value T, size int) (T, bool)
+	// DroppedValuesMarker creates the value added in place of the dropped values, nothing is added when nil
+	DroppedValuesMarker func(dropped int) T
+}
+
+// LimitStats counts the items truncated or dropped by a Limiter
+type LimitStats struct {
+	TruncatedMessages uint64
+	DroppedValues     uint64
+	TruncatedValues   uint64
+	DroppedSpanEvents uint64
+}
+
+// Limiter applies the limits to the log values and counts the truncated and dropped items
+// A Limiter can be shared by several handlers
+type Limiter[T any] struct {
+	limits Limits[T]
+
+	truncatedMessages atomic.Uint64
+	droppedValues     atomic.Uint64
+	truncatedValues   atomic.Uint64
+	droppedSpanEvents atomic.Uint64
+}
+
+// NewLimiter creates a Limiter with the given limits
+func NewLimiter[T any](limits Limits[T]) *Limiter[T] {
+	return &Limiter[T]{limits: limits}
+}
+
+// Stats returns the number of items truncated or dropped so far
+func (l *Limiter[T]) Stats() LimitStats {
+	return LimitStats{
+		TruncatedMessages: l.truncatedMessages.Load(),
+		DroppedValues:     l.droppedValues.Load(),
+		TruncatedValues:   l.truncatedValues.Load(),
+		DroppedSpanEvents: l.droppedSpanEvents.Load(),
+	}
+}
+
+// Apply returns the log values logged at the given level with the message, the values and the number of values truncated
+// Only the values logged at the level are limited, they're attached to every level of the returned log values
+// along with a single marker of the dropped values
+// The log values are returned as is when they are within the limits
+func (l *Limiter[T]) Apply(level Level, lv LogValues[T]) LogValues[T] {
+	if msg, truncated := TruncateString(lv.msg, l.limits.MaxMessageLength); truncated {
+		lv.msg = msg
+		l.truncatedMessages.Add(1)
+	}
+
+	all, logged := lv.all, lv.level(level)
+	truncate, maxSize := l.limits.TruncateValue, l.limits.MaxValueSize
+	truncateValues := maxSize > 0 && truncate != nil && (all.oversized(truncate, maxSize) || logged.oversized(truncate, maxSize))
+	maxValues := l.limits.MaxValuesPerLevel
+	limitValues := maxValues > 0 && all.len()+logged.len() > maxValues
+	if !truncateValues && !limitValues {
+		return lv
+	}
+
+	// the dropped values are removed first so their lazy values aren't evaluated
+	var dropped int
+	if limitValues {
+		var allDropped, levelDropped int
+		all, allDropped = all.limited(maxValues)
+		logged, levelDropped = logged.limited(maxValues - all.len())
+		dropped = allDropped + levelDropped
+		l.droppedValues.Add(uint64(dropped))
+	}
+
+	values := logged.appendTo(all.appendTo(nil))
+	if truncateValues {
+		for i, value := range values {
+			var truncated bool
+			if values[i], truncated = truncate(value, maxSize); truncated {
+				l.truncatedValues.Add(1)
+			}
+		}
+	}
+	if dropped > 0 && l.limits.DroppedValuesMarker != nil {
+		values = append(values, l.limits.DroppedValuesMarker(dropped))
+	}
+
+	lv.levels, lv.all = nil, levelValues[T]{values: values}
+	return lv
+}
+
+// spanEventCounter counts the events added to and dropped from a span
+// It's shared by the handlers logging onto the same span, possibly from several goroutines
+type spanEventCounter struct {
+	events  atomic.Int64
+	dropped atomic.Int64
+}
+
+// allowSpanEvent reports whether the span accepts another event and counts the added and dropped ones
+func (l *Limiter[T]) allowSpanEvent(counter *spanEventCounter) bool {
+	if l.limits.MaxSpanEvents <= 0 {
+		return true
+	}
+	if counter.events.Add(1) <= int64(l.limits.MaxSpanEvents) {
+		return true
+	}
+	counter.dropped.Add(1)
+	l.droppedSpanEvents.Add(1)
+	return false
+}
+
+// oversized reports whether a value may exceed the size, lazy values are assumed to exceed it
+func (v levelValues[T]) oversized(truncate func(T, int) (T, bool), size int) bool {
+	if len(v.lazy) > 0 {
+		return true
+	}
+	for _, value := range v.values {
+		if _, truncated := truncate(value, size); truncated {
+			return true
+		}
+	}
+	return false
+}
+
+// limited returns the first max values, values evaluated when added coming first, and the number of dropped values
+func (v levelValues[T]) limited(max int) (levelValues[T], int) {
+	if max < 0 {
+		max = 0
+	}
+	dropped := v.len() - max
+	if dropped <= 0 {
+		return v, 0
+	}
+
+	limited := levelValues[T]{level: v.level}
+	if len(v.values) >= max {
+		limited.values = v.values[:max:max]
+	} else {
+		limited.values = v.values[:len(v.values):len(v.values)]
+		lazy := max - len(v.values)
+		limited.lazy = v.lazy[:lazy:lazy]
+	}
+	return limited, dropped
+}
+
+// TruncateString truncates the string to at most max bytes without splitting a rune and appends TruncationMarker
+// Returns the string as is when it's within the limit or max is zero
+func TruncateString(s string, max int) (string, bool) {
+	if max <= 0 || len(s) <= max {
+		return s, false
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max] + TruncationMarker, true
+}
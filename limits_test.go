@@ -0,0 +1,154 @@
+package observability
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// truncate truncates the string values for the tests
+func truncate(value string, size int) (string, bool) {
+	return TruncateString(value, size)
+}
+
+// Test that log values within the limits are returned as is
+func TestLimiter_WithinLimits(t *testing.T) {
+	limiter := NewLimiter(Limits[string]{MaxMessageLength: 10, MaxValuesPerLevel: 2, MaxValueSize: 5, TruncateValue: truncate})
+	lv := NewLogValuesBuilder[string]().WithMsg("short").WithAllLevelsValue("a").WithInfoValue("b").Build()
+
+	assert.Equal(t, lv, limiter.Apply(InfoLevel, lv))
+	assert.Equal(t, LimitStats{}, limiter.Stats())
+}
+
+// Test that the message and the values of the logged level are truncated and the dropped values marked once
+func TestLimiter_Apply(t *testing.T) {
+	limiter := NewLimiter(Limits[string]{
+		MaxMessageLength:    5,
+		MaxValuesPerLevel:   3,
+		MaxValueSize:        4,
+		TruncateValue:       truncate,
+		DroppedValuesMarker: func(dropped int) string { return "dropped=" + strconv.Itoa(dropped) },
+	})
+	evaluated := false
+	lv := NewLogValuesBuilder[string]().
+		WithMsg("héllo world").
+		WithAllLevelsValue("a").
+		WithAllLevelsValue("b").
+		WithInfoValue("long value").
+		WithInfoValue("d").
+		WithInfoValue("e").
+		WithLazyValue(InfoLevel, func() string { evaluated = true; return "f" }).
+		WithDebugValue("long debug value").
+		WithDebugValue("g").
+		Build()
+
+	limited := limiter.Apply(InfoLevel, lv)
+	assert.Equal(t, "héll"+TruncationMarker, limited.Msg())
+	assert.Equal(t, []string{"a", "b", "long" + TruncationMarker, "dropped=3"}, limited.Values(InfoLevel))
+	assert.False(t, evaluated)
+	// Assert the original log values aren't modified
+	assert.Equal(t, []string{"a", "b", "long value", "d", "e", "f"}, lv.Values(InfoLevel))
+
+	// Assert only the values of the logged level are counted
+	assert.Equal(t, LimitStats{TruncatedMessages: 1, DroppedValues: 3, TruncatedValues: 1}, limiter.Stats())
+}
+
+// Test that the values attached to every level are limited first and marked once
+func TestLimiter_AllLevelsValues(t *testing.T) {
+	limiter := NewLimiter(Limits[string]{
+		MaxValuesPerLevel:   1,
+		DroppedValuesMarker: func(dropped int) string { return "dropped=" + strconv.Itoa(dropped) },
+	})
+	lv := NewLogValuesBuilder[string]().WithAllLevelsValue("a").WithAllLevelsValue("b").WithErrorValue("c").WithDebugValue("d").Build()
+
+	assert.Equal(t, []string{"a", "dropped=2"}, limiter.Apply(ErrorLevel, lv).Values(ErrorLevel))
+	assert.Equal(t, uint64(2), limiter.Stats().DroppedValues)
+}
+
+// Test that TruncateString doesn't split runes
+func TestTruncateString(t *testing.T) {
+	s, truncated := TruncateString("héllo", 2)
+	assert.True(t, truncated)
+	assert.Equal(t, "h"+TruncationMarker, s)
+
+	s, truncated = TruncateString("hello", 0)
+	assert.False(t, truncated)
+	assert.Equal(t, "hello", s)
+}
+
+// Test that the handler applies the limits and drops the span events beyond the limit
+func TestObservabilityContext_Limiter(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	limiter := NewLimiter(Limits[string]{MaxMessageLength: 4, MaxSpanEvents: 2})
+	h := NewObservabilityHandler[string](ctx, "checkout", logger, WithLimiter[string](limiter))
+	_, end := h.StartSpan("GET /orders")
+
+	for i := 0; i < 4; i++ {
+		h.LogInfo(msg("event " + strconv.Itoa(i)))
+	}
+	end()
+
+	span := tp.all()[0]
+	assert.Len(t, span.recordedEvents(), 2)
+	assert.Equal(t, "even"+TruncationMarker, span.recordedEvents()[0].name)
+	assert.Equal(t, 2, int(span.attrs[len(span.attrs)-1].Value.AsInt64()))
+	assert.Len(t, logger.all(), 4)
+	assert.Equal(t, LimitStats{TruncatedMessages: 4, DroppedSpanEvents: 2}, limiter.Stats())
+
+	// Assert the span events are counted per span
+	_, end = h.StartSpan("GET /orders/1")
+	defer end()
+	h.LogInfo(msg("next"))
+	assert.Len(t, tp.all()[1].recordedEvents(), 1)
+}
+
+// Test that the handlers derived onto a span share its span events
+func TestObservabilityContext_LimiterDerivedHandlers(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	limiter := NewLimiter(Limits[string]{MaxSpanEvents: 2})
+	h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{level: DebugLevel}, WithLimiter[string](limiter))
+	spanCtx, end := h.StartSpan("GET /orders")
+	defer end()
+
+	h.LogInfo(msg("first"))
+	h.WithContext(spanCtx).LogInfo(msg("second"))
+	h.WithContext(spanCtx).LogInfo(msg("third"))
+
+	assert.Len(t, tp.all()[0].recordedEvents(), 2)
+	assert.Equal(t, uint64(1), limiter.Stats().DroppedSpanEvents)
+}
+
+// Test that a handler can log from several goroutines, run with -race
+func TestObservabilityContext_ConcurrentLogging(t *testing.T) {
+	for name, opts := range map[string][]ObservabilityOption[string]{
+		"without limiter": nil,
+		"with limiter":    {WithLimiter[string](NewLimiter(Limits[string]{MaxSpanEvents: 10}))},
+	} {
+		ctx, tp := newRecordingContext()
+		h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{level: DebugLevel}, opts...)
+		_, end := h.StartSpan("GET /orders")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 25; j++ {
+					h.LogInfo(msg("event"))
+				}
+			}()
+		}
+		wg.Wait()
+		end()
+
+		events := tp.all()[0].recordedEvents()
+		if opts == nil {
+			assert.Len(t, events, 100, name)
+		} else {
+			assert.Len(t, events, 10, name)
+		}
+	}
+}
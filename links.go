@@ -0,0 +1,72 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LinkFromSpanContext creates a link to the span of the given span context
+func LinkFromSpanContext(sc trace.SpanContext, attrs ...attribute.KeyValue) trace.Link {
+	return trace.Link{SpanContext: sc, Attributes: attrs}
+}
+
+// LinkFromTraceValues creates a link to the span identified by the given trace values
+// The sampled flag and the trace state are kept
+// Returns an error if the trace values don't contain valid ids or trace state
+func LinkFromTraceValues(tv TraceValues, attrs ...attribute.KeyValue) (trace.Link, error) {
+	traceID, err := trace.TraceIDFromHex(tv.TraceId)
+	if err != nil {
+		return trace.Link{}, fmt.Errorf("invalid trace id %q: %w", tv.TraceId, err)
+	}
+	spanID, err := trace.SpanIDFromHex(tv.SpanId)
+	if err != nil {
+		return trace.Link{}, fmt.Errorf("invalid span id %q: %w", tv.SpanId, err)
+	}
+	traceState, err := trace.ParseTraceState(tv.TraceState)
+	if err != nil {
+		return trace.Link{}, fmt.Errorf("invalid trace state %q: %w", tv.TraceState, err)
+	}
+
+	var traceFlags trace.TraceFlags
+	traceFlags = traceFlags.WithSampled(tv.Sampled)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: traceFlags,
+		TraceState: traceState,
+		Remote:     true,
+	})
+	return LinkFromSpanContext(sc, attrs...), nil
+}
+
+// LinkFromCarrier creates a link to the span context propagated in the given carrier
+// The global propagator is used when propagator is nil
+// Returns false if the carrier doesn't contain a valid span context
+func LinkFromCarrier(ctx context.Context, propagator propagation.TextMapPropagator, carrier propagation.TextMapCarrier, attrs ...attribute.KeyValue) (trace.Link, bool) {
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+
+	sc := trace.SpanContextFromContext(propagator.Extract(ctx, carrier))
+	if !sc.IsValid() {
+		return trace.Link{}, false
+	}
+	return LinkFromSpanContext(sc, attrs...), true
+}
+
+// LinksFromCarriers creates links to the span contexts propagated in the given carriers
+// Carriers without a valid span context are skipped
+func LinksFromCarriers(ctx context.Context, propagator propagation.TextMapPropagator, carriers ...propagation.TextMapCarrier) []trace.Link {
+	links := make([]trace.Link, 0, len(carriers))
+	for _, carrier := range carriers {
+		if link, ok := LinkFromCarrier(ctx, propagator, carrier); ok {
+			links = append(links, link)
+		}
+	}
+	return links
+}
@@ -0,0 +1,79 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	linkTraceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	linkSpanId  = "00f067aa0ba902b7"
+)
+
+// Test that LinkFromTraceValues creates a remote link to the given ids
+func TestLinkFromTraceValues(t *testing.T) {
+	link, err := LinkFromTraceValues(TraceValues{TraceId: linkTraceId, SpanId: linkSpanId}, attribute.String("foo", "bar"))
+	assert.NoError(t, err)
+	assert.Equal(t, linkTraceId, link.SpanContext.TraceID().String())
+	assert.Equal(t, linkSpanId, link.SpanContext.SpanID().String())
+	assert.True(t, link.SpanContext.IsRemote())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("foo", "bar")}, link.Attributes)
+
+	// Assert the sampled flag and the trace state are kept
+	link, err = LinkFromTraceValues(TraceValues{TraceId: linkTraceId, SpanId: linkSpanId, Sampled: true, TraceState: "vendor=value"})
+	assert.NoError(t, err)
+	assert.True(t, link.SpanContext.IsSampled())
+	assert.Equal(t, "value", link.SpanContext.TraceState().Get("vendor"))
+
+	_, err = LinkFromTraceValues(TraceValues{TraceId: linkTraceId, SpanId: linkSpanId, TraceState: "invalid state"})
+	assert.Error(t, err)
+	_, err = LinkFromTraceValues(TraceValues{TraceId: "invalid", SpanId: linkSpanId})
+	assert.Error(t, err)
+	_, err = LinkFromTraceValues(TraceValues{TraceId: linkTraceId})
+	assert.Error(t, err)
+}
+
+// Test that LinksFromCarriers creates links from the propagated carriers skipping invalid ones
+func TestLinksFromCarriers(t *testing.T) {
+	valid := propagation.MapCarrier{"traceparent": "00-" + linkTraceId + "-" + linkSpanId + "-01"}
+	invalid := propagation.MapCarrier{}
+
+	links := LinksFromCarriers(context.Background(), propagation.TraceContext{}, valid, invalid)
+	assert.Len(t, links, 1)
+	assert.Equal(t, linkTraceId, links[0].SpanContext.TraceID().String())
+	assert.True(t, links[0].SpanContext.IsSampled())
+
+	_, ok := LinkFromCarrier(context.Background(), propagation.TraceContext{}, invalid)
+	assert.False(t, ok)
+}
+
+// Test that the handler passes the links to the started spans
+func TestObservabilityContext_Links(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	h := NewObservabilityHandler[string](ctx, "consumer", &recordingLogger{})
+
+	first, _ := LinkFromTraceValues(TraceValues{TraceId: linkTraceId, SpanId: linkSpanId})
+	second := LinkFromSpanContext(trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x0a},
+		SpanID:  trace.SpanID{0x0b},
+	}))
+
+	_, end := h.StartSpanWithLinks("batch", []trace.Link{first, second})
+	end()
+
+	h.AddLink(first)
+	_, end = h.StartSpan("pending")
+	end()
+	_, end = h.StartSpan("next")
+	end()
+
+	spans := tp.all()
+	assert.Equal(t, []trace.Link{first, second}, spans[0].config.Links())
+	assert.Equal(t, []trace.Link{first}, spans[1].config.Links())
+	assert.Empty(t, spans[2].config.Links())
+}
@@ -1,18 +1,40 @@
 package observability
 
 // LogBuilder is a builder for log values
-type LogBuilder[T any] struct{}
+type LogBuilder[T any] struct {
+	// pool reuses the log values builders, nil when pooling is disabled
+	pool *LogValuesBuilderPool[T]
+	// allLevelsValues are added to every log values builder created, e.g. the tracing format
+	allLevelsValues []T
+}
 
 // NewLogBuilder creates a new LogBuilder with the given type
 func NewLogBuilder[T any]() *LogBuilder[T] {
 	return &LogBuilder[T]{}
 }
 
+// NewLogBuilderWithPool creates a new LogBuilder reusing the log values builders of the given pool
+func NewLogBuilderWithPool[T any](pool *LogValuesBuilderPool[T]) *LogBuilder[T] {
+	return &LogBuilder[T]{pool: pool}
+}
+
+// WithAllLevelsValues replaces the values added to every log values builder created
+func (lb *LogBuilder[T]) WithAllLevelsValues(values ...T) *LogBuilder[T] {
+	lb.allLevelsValues = append(lb.allLevelsValues[:0], values...)
+	return lb
+}
+
 // CreateLogValueOption creates a new log value option with the given attribute
 func (lb *LogBuilder[T]) CreateLogValueOption(attr T) *LogValueOption[T] {
 	return NewLogValueOption[T](attr)
 }
 
+// CreateLogValueOptionWithLevels creates a new log value option with the given attribute
+// attached to the levels of the given level option
+func (lb *LogBuilder[T]) CreateLogValueOptionWithLevels(attr T, levels *LogValueLevelOption) *LogValueOption[T] {
+	return NewLogValueOptionWithLevels[T](attr, levels)
+}
+
 // CreateLogValuesOptions creates a new empty log values options
 func (lb *LogBuilder[T]) CreateLogValuesOptions() *LogValuesOptions[T] {
 	return NewLogValuesOptions[T]()
@@ -27,10 +49,35 @@ func (lb *LogBuilder[T]) CreateLogValuesOptionsWith(options ...*LogValueOption[T
 
 // FactoryLogValuesBuilder creates a builder with the given options
 func (lb *LogBuilder[T]) FactoryLogValuesBuilder(options LogValuesOptions[T]) *LogValuesBuilder[T] {
-	return FactoryLogValuesBuilder[T](options)
+	return lb.seed(FactoryLogValuesBuilder[T](options))
 }
 
 // CreateLogValuesBuilder creates a new log values builder
 func (lb *LogBuilder[T]) CreateLogValuesBuilder() *LogValuesBuilder[T] {
-	return NewLogValuesBuilder[T]()
+	return lb.seed(NewLogValuesBuilder[T]())
+}
+
+// AcquireLogValuesBuilder returns a log values builder from the pool
+// A new builder is returned when pooling is disabled
+func (lb *LogBuilder[T]) AcquireLogValuesBuilder() *LogValuesBuilder[T] {
+	if lb.pool == nil {
+		return lb.seed(NewLogValuesBuilder[T]())
+	}
+	return lb.seed(lb.pool.Get())
+}
+
+// ReleaseLogValuesBuilder returns the builder to the pool
+// Requires the built log values to no longer be used
+func (lb *LogBuilder[T]) ReleaseLogValuesBuilder(b *LogValuesBuilder[T]) {
+	if lb.pool != nil {
+		lb.pool.Put(b)
+	}
+}
+
+// seed adds the all levels values of the LogBuilder to the given builder
+func (lb *LogBuilder[T]) seed(b *LogValuesBuilder[T]) *LogValuesBuilder[T] {
+	for _, value := range lb.allLevelsValues {
+		b.WithAllLevelsValue(value)
+	}
+	return b
 }
@@ -33,15 +33,15 @@ func TestLogBuilder_CreateLogValuesOptionsWith(t *testing.T) {
 func TestLogBuilder_FactoryLogValuesBuilder(t *testing.T) {
 	lb := &LogBuilder[string]{}
 	options := LogValuesOptions[string]{
-		{debug: true, Attr: "foo"},
-		{err: true, Attr: "bar"},
-		{info: true, Attr: "baz"},
+		{LogValueLevelOption: LogValueLevelOption{debug: true}, Attr: "foo"},
+		{LogValueLevelOption: LogValueLevelOption{err: true}, Attr: "bar"},
+		{LogValueLevelOption: LogValueLevelOption{info: true}, Attr: "baz"},
 	}
 
 	builder := lb.FactoryLogValuesBuilder(options)
-	assert.Equal(t, []string{"foo"}, builder.debugValues)
-	assert.Equal(t, []string{"bar"}, builder.errorValues)
-	assert.Equal(t, []string{"baz"}, builder.infoValues)
+	assert.Equal(t, []string{"foo"}, builder.level(DebugLevel).values)
+	assert.Equal(t, []string{"bar"}, builder.level(ErrorLevel).values)
+	assert.Equal(t, []string{"baz"}, builder.level(InfoLevel).values)
 }
 
 // Test that NewLogBuilder creates a new LogBuilder with the given type
@@ -49,3 +49,14 @@ func TestLogBuilder_NewLogBuilder(t *testing.T) {
 	lb := NewLogBuilder[string]()
 	assert.NotNil(t, lb)
 }
+
+// Test that AcquireLogValuesBuilder reuses released builders when pooling is enabled
+func TestLogBuilder_AcquireLogValuesBuilder(t *testing.T) {
+	lb := NewLogBuilderWithPool[string](NewLogValuesBuilderPool[string]())
+	b := lb.AcquireLogValuesBuilder().WithMsg("hello")
+	lb.ReleaseLogValuesBuilder(b)
+	assert.Empty(t, lb.AcquireLogValuesBuilder().Build().Msg())
+
+	// Assert a new builder is returned without pool
+	assert.NotNil(t, NewLogBuilder[string]().AcquireLogValuesBuilder())
+}
@@ -0,0 +1,57 @@
+package observability
+
+import "context"
+
+// LevelLogger is implemented by loggers writing every level as is, custom levels included
+// The handler and the wrapping loggers prefer its Log method over the Log* methods,
+// which receive the custom levels at the closest built-in level below them
+type LevelLogger[T any] interface {
+	// Log logs a message at the given level with the values of that level
+	// ctx is context.Background() for the calls made without context
+	Log(ctx context.Context, level Level, lv LogValues[T])
+}
+
+// logCall is a log call captured to be forwarded to an ObservabilityLogger later
+type logCall[T any] struct {
+	level Level
+	// ctx is nil for calls made without context
+	ctx context.Context
+	lv  LogValues[T]
+	// spanStarted and spanEnded are set for the notifications of the spans queued by the AsyncLogger instead of a log
+	spanStarted *TraceValues
+	spanEnded   *TraceValues
+}
+
+// dispatch forwards the call to the Log method of LevelLogger loggers, or to the logger method matching its level
+// Custom levels are forwarded to the closest built-in level below them for the other loggers
+func (c logCall[T]) dispatch(logger ObservabilityLogger[T]) {
+	if levelLogger, ok := logger.(LevelLogger[T]); ok {
+		ctx := c.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		levelLogger.Log(ctx, c.level, c.lv)
+		return
+	}
+
+	switch {
+	case c.level < InfoLevel:
+		if c.ctx == nil {
+			logger.LogDebug(c.lv)
+			return
+		}
+		logger.LogDebugContext(c.ctx, c.lv)
+	case c.level < ErrorLevel:
+		if c.ctx == nil {
+			logger.LogInfo(c.lv)
+			return
+		}
+		logger.LogInfoContext(c.ctx, c.lv)
+	default:
+		if c.ctx == nil {
+			logger.LogError(c.lv)
+			return
+		}
+		logger.LogErrorContext(c.ctx, c.lv)
+	}
+}
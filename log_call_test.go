@@ -0,0 +1,56 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that dispatch forwards custom levels to the closest built-in level below them
+func TestLogCall_Dispatch(t *testing.T) {
+	logger := &recordingLogger{level: DebugLevel}
+	ctx := context.Background()
+
+	logCall[string]{level: Level(-8), lv: msg("trace")}.dispatch(logger)
+	logCall[string]{level: Level(2), ctx: ctx, lv: msg("notice")}.dispatch(logger)
+	logCall[string]{level: Level(12), lv: msg("audit")}.dispatch(logger)
+
+	entries := logger.all()
+	assert.Equal(t, DebugLevel, entries[0].level)
+	assert.Equal(t, InfoLevel, entries[1].level)
+	assert.Equal(t, ctx, entries[1].ctx)
+	assert.Equal(t, ErrorLevel, entries[2].level)
+}
+
+// levelRecordingLogger is a recordingLogger implementing LevelLogger
+type levelRecordingLogger struct {
+	recordingLogger
+}
+
+func (l *levelRecordingLogger) Log(ctx context.Context, level Level, lv LogValues[string]) {
+	l.record(ctx, level, lv)
+}
+
+// Test that dispatch prefers LevelLogger and that custom levels reach it as is through the handler and the wrappers
+func TestLogCall_DispatchLevelLogger(t *testing.T) {
+	const notice Level = 2
+	logger := &levelRecordingLogger{recordingLogger{level: DebugLevel}}
+	async := NewAsyncLogger[string](NewHookedLogger[string](logger))
+	multi := NewMultiLogger[string](NewLoggerDestination[string](async, DebugLevel))
+	h := NewObservabilityHandler[string](context.Background(), "checkout", multi, WithValueConverter[string](sprintConverter))
+
+	h.LogLevel(notice, NewLogValuesBuilder[string]().WithMsg("signed in").WithValue(notice, "user=alice").WithInfoValue("hidden").Build())
+	h.StartTimer("checkout").WithLevel(notice).Stop(OutcomeSuccess)
+	h.LogInfo(msg("info"))
+	assert.NoError(t, async.Shutdown(context.Background()))
+
+	entries := logger.all()
+	assert.Equal(t, []string{"signed in", "checkout", "info"}, logger.messages())
+	assert.Equal(t, notice, entries[0].level)
+	assert.Equal(t, []string{"user=alice"}, entries[0].lv.Values(entries[0].level))
+	assert.Equal(t, notice, entries[1].level)
+	assert.Equal(t, InfoLevel, entries[2].level)
+	// Assert the calls without context receive the background context
+	assert.Equal(t, context.Background(), entries[0].ctx)
+}
@@ -0,0 +1,120 @@
+package observability
+
+import "context"
+
+// LogHook is called before a log is emitted
+//
+// It receives the level, the context and the log values and returns the log values to emit,
+// derived from the given ones with LogValues.Builder, or false to veto the log.
+type LogHook[T any] func(ctx context.Context, level Level, lv LogValues[T]) (LogValues[T], bool)
+
+// LogHookChain is a list of hooks run in order
+// The chain stops at the first hook vetoing the log
+type LogHookChain[T any] []LogHook[T]
+
+// NewLogHookChain creates a new LogHookChain with the given hooks
+func NewLogHookChain[T any](hooks ...LogHook[T]) LogHookChain[T] {
+	return LogHookChain[T](hooks)
+}
+
+// Append returns a chain running the given hooks after the current ones
+func (c LogHookChain[T]) Append(hooks ...LogHook[T]) LogHookChain[T] {
+	chain := make(LogHookChain[T], 0, len(c)+len(hooks))
+	chain = append(chain, c...)
+	return append(chain, hooks...)
+}
+
+// Run runs the hooks and returns the log values to emit
+// Returns false if a hook vetoed the log
+func (c LogHookChain[T]) Run(ctx context.Context, level Level, lv LogValues[T]) (LogValues[T], bool) {
+	for _, hook := range c {
+		var ok bool
+		if lv, ok = hook(ctx, level, lv); !ok {
+			return lv, false
+		}
+	}
+	return lv, true
+}
+
+// HookedLogger is an ObservabilityLogger running a hook chain before calling another logger
+// The context given to the hooks is nil for calls made without context
+type HookedLogger[T any] struct {
+	logger ObservabilityLogger[T]
+	chain  LogHookChain[T]
+}
+
+// NewHookedLogger creates a new HookedLogger running the given hooks before calling the logger
+func NewHookedLogger[T any](logger ObservabilityLogger[T], hooks ...LogHook[T]) *HookedLogger[T] {
+	return &HookedLogger[T]{
+		logger: logger,
+		chain:  NewLogHookChain(hooks...),
+	}
+}
+
+// Enabled checks if the wrapped logger emits logs at the given level
+func (l *HookedLogger[T]) Enabled(level Level) bool {
+	return l.logger.Enabled(level)
+}
+
+// LogInfo logs an info message with the given values
+func (l *HookedLogger[T]) LogInfo(lv LogValues[T]) {
+	l.log(logCall[T]{level: InfoLevel, lv: lv})
+}
+
+// LogError logs an error message with the given values
+func (l *HookedLogger[T]) LogError(lv LogValues[T]) {
+	l.log(logCall[T]{level: ErrorLevel, lv: lv})
+}
+
+// LogDebug logs a debug message with the given values
+func (l *HookedLogger[T]) LogDebug(lv LogValues[T]) {
+	l.log(logCall[T]{level: DebugLevel, lv: lv})
+}
+
+// LogInfoContext logs an info message with the given values and observability context
+func (l *HookedLogger[T]) LogInfoContext(ctx context.Context, lv LogValues[T]) {
+	l.log(logCall[T]{level: InfoLevel, ctx: ctx, lv: lv})
+}
+
+// LogErrorContext logs an error message with the given values and observability context
+func (l *HookedLogger[T]) LogErrorContext(ctx context.Context, lv LogValues[T]) {
+	l.log(logCall[T]{level: ErrorLevel, ctx: ctx, lv: lv})
+}
+
+// LogDebugContext logs a debug message with the given values and observability context
+func (l *HookedLogger[T]) LogDebugContext(ctx context.Context, lv LogValues[T]) {
+	l.log(logCall[T]{level: DebugLevel, ctx: ctx, lv: lv})
+}
+
+// Log runs the chain and forwards a message at the given level, refer to LevelLogger
+func (l *HookedLogger[T]) Log(ctx context.Context, level Level, lv LogValues[T]) {
+	l.log(logCall[T]{level: level, ctx: ctx, lv: lv})
+}
+
+// RequiresSpanContext reports whether the wrapped logger requires the context of every call, refer to SpanContextLogger
+func (l *HookedLogger[T]) RequiresSpanContext() bool {
+	return requiresSpanContext(l.logger)
+}
+
+// SpanStarted forwards the start of the span to the wrapped logger if it's a SpanStartObserver
+func (l *HookedLogger[T]) SpanStarted(tv TraceValues) {
+	notifySpanStarted(l.logger, tv)
+}
+
+// SpanEnded forwards the end of the span to the wrapped logger if it's a SpanEndObserver
+func (l *HookedLogger[T]) SpanEnded(tv TraceValues) {
+	notifySpanEnded(l.logger, tv)
+}
+
+// log runs the chain and forwards the call unless it's vetoed
+// The hooks receive context.Background() for the calls made without context
+func (l *HookedLogger[T]) log(call logCall[T]) {
+	ctx := call.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var ok bool
+	if call.lv, ok = l.chain.Run(ctx, call.level, call.lv); ok {
+		call.dispatch(l.logger)
+	}
+}
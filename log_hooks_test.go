@@ -0,0 +1,114 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// enrichHook adds the hostname to every level
+func enrichHook(_ context.Context, _ Level, lv LogValues[string]) (LogValues[string], bool) {
+	return lv.Builder().WithAllLevelsValue("host=api-1").Build(), true
+}
+
+// healthCheckHook vetoes the health check logs
+func healthCheckHook(_ context.Context, _ Level, lv LogValues[string]) (LogValues[string], bool) {
+	return lv, lv.Msg() != "GET /healthz"
+}
+
+// Test that Run applies the hooks in order and stops at the first veto
+func TestLogHookChain_Run(t *testing.T) {
+	calls := 0
+	counter := func(_ context.Context, _ Level, lv LogValues[string]) (LogValues[string], bool) {
+		calls++
+		return lv, true
+	}
+	chain := NewLogHookChain[string](healthCheckHook).Append(enrichHook, counter)
+
+	lv, ok := chain.Run(context.Background(), InfoLevel, msg("GET /orders"))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"host=api-1"}, lv.Values(InfoLevel))
+
+	_, ok = chain.Run(context.Background(), InfoLevel, msg("GET /healthz"))
+	assert.False(t, ok)
+	assert.Equal(t, 1, calls)
+}
+
+// Test that the HookedLogger emits the modified log values and skips vetoed logs
+func TestHookedLogger(t *testing.T) {
+	logger := &recordingLogger{level: DebugLevel}
+	errors := 0
+	countErrors := func(_ context.Context, level Level, lv LogValues[string]) (LogValues[string], bool) {
+		if level >= ErrorLevel {
+			errors++
+		}
+		return lv, true
+	}
+	hooked := NewHookedLogger[string](logger, healthCheckHook, enrichHook, countErrors)
+
+	hooked.LogInfo(msg("GET /healthz"))
+	hooked.LogDebugContext(context.Background(), msg("GET /orders"))
+	hooked.LogError(msg("failed"))
+
+	assert.Equal(t, []string{"GET /orders", "failed"}, logger.messages())
+	assert.Equal(t, []string{"host=api-1"}, logger.all()[0].lv.Values(DebugLevel))
+	assert.Equal(t, 1, errors)
+	assert.True(t, hooked.Enabled(DebugLevel))
+}
+
+// Test that the hooks receive a context for the calls made without context while the logger doesn't
+func TestHookedLogger_NilContext(t *testing.T) {
+	logger := &recordingLogger{level: DebugLevel}
+	var contexts []context.Context
+	recordContext := func(ctx context.Context, _ Level, lv LogValues[string]) (LogValues[string], bool) {
+		contexts = append(contexts, ctx)
+		return lv, true
+	}
+	hooked := NewHookedLogger[string](logger, recordContext)
+
+	hooked.LogInfo(msg("info"))
+	hooked.LogError(msg("error"))
+	hooked.LogDebug(msg("debug"))
+
+	assert.Equal(t, []context.Context{context.Background(), context.Background(), context.Background()}, contexts)
+	for _, entry := range logger.all() {
+		assert.Nil(t, entry.ctx)
+	}
+}
+
+// Test that the handler hooks run before both the span event and the logger
+func TestObservabilityContext_LogHooks(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	h := NewObservabilityHandler[string](ctx, "api", logger, WithLogHooks[string](healthCheckHook, enrichHook))
+	_, end := h.StartSpan("request")
+	defer end()
+
+	h.LogInfo(msg("GET /healthz"))
+	h.LogInfoContext(msg("GET /orders"))
+
+	assert.Equal(t, []string{"GET /orders"}, logger.messages())
+	assert.Equal(t, []string{"host=api-1"}, logger.all()[0].lv.Values(InfoLevel))
+	events := tp.all()[0].recordedEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, "GET /orders", events[0].name)
+}
+
+// Test that log values derived with Builder don't affect each other
+func TestLogValues_Builder(t *testing.T) {
+	lv := NewLogValuesBuilder[string]().
+		WithMsg("hello").
+		WithInfoValue("foo").
+		WithAllLevelsValue("bar").
+		Build()
+
+	first := lv.Builder().WithInfoValue("first").WithAllLevelsValue("first").Build()
+	second := lv.Builder().WithInfoValue("second").WithAllLevelsValue("second").WithErr(assert.AnError).Build()
+
+	assert.Equal(t, []string{"bar", "foo"}, lv.Values(InfoLevel))
+	assert.Equal(t, []string{"bar", "first", "foo", "first"}, first.Values(InfoLevel))
+	assert.Equal(t, []string{"bar", "second", "foo", "second"}, second.Values(InfoLevel))
+	assert.Equal(t, "hello", second.Msg())
+	assert.Equal(t, assert.AnError, second.Err())
+}
@@ -1,9 +1,10 @@
 package observability
 
 // LogValueOption is an option for a log value
+// The embedded LogValueLevelOption holds the levels the value is attached to
 type LogValueOption[T any] struct {
-	Attr             T
-	debug, info, err bool
+	Attr T
+	LogValueLevelOption
 }
 
 // NewLogValueOption creates a new log value option with the given attribute
@@ -11,6 +12,12 @@ func NewLogValueOption[T any](attr T) *LogValueOption[T] {
 	return &LogValueOption[T]{Attr: attr}
 }
 
+// NewLogValueOptionWithLevels creates a new log value option with the given attribute
+// attached to the levels of the given level option
+func NewLogValueOptionWithLevels[T any](attr T, levels *LogValueLevelOption) *LogValueOption[T] {
+	return NewLogValueOption[T](attr).WithLevelOption(levels)
+}
+
 // WithDebug sets the debug flag to true
 func (o *LogValueOption[T]) WithDebug() *LogValueOption[T] {
 	o.debug = true
@@ -29,6 +36,32 @@ func (o *LogValueOption[T]) WithError() *LogValueOption[T] {
 	return o
 }
 
+// WithLevel attaches the value to the given levels
+func (o *LogValueOption[T]) WithLevel(levels ...Level) *LogValueOption[T] {
+	o.LogValueLevelOption.WithLevel(levels...)
+	return o
+}
+
+// WithAllLevels attaches the value to every level
+func (o *LogValueOption[T]) WithAllLevels() *LogValueOption[T] {
+	o.all = true
+	return o
+}
+
+// WithLevelOption attaches the value to the levels of the given level option
+// in addition to the levels already set
+func (o *LogValueOption[T]) WithLevelOption(levels *LogValueLevelOption) *LogValueOption[T] {
+	if levels == nil {
+		return o
+	}
+	o.info = o.info || levels.info
+	o.err = o.err || levels.err
+	o.debug = o.debug || levels.debug
+	o.all = o.all || levels.all
+	o.LogValueLevelOption.WithLevel(levels.levels...)
+	return o
+}
+
 // LogValuesOptions is a list of log value options
 type LogValuesOptions[T any] []*LogValueOption[T]
 
@@ -37,9 +70,13 @@ func (lvos *LogValuesOptions[T]) Len() int {
 	return len(*lvos)
 }
 
-// AddOption adds the given option to the list
+// LogValueLevelOption is the set of levels a log value is attached to
 type LogValueLevelOption struct {
 	info, err, debug bool
+	// all attaches the value to every level
+	all bool
+	// levels contains the custom levels
+	levels []Level
 }
 
 // LogValuesBuilder is a builder for log values
@@ -61,3 +98,64 @@ func NewLogValuesLevelOption(info, err, debug bool) *LogValueLevelOption {
 		debug: debug,
 	}
 }
+
+// NewLogValuesAllLevelsOption creates a new log value level option for every level
+func NewLogValuesAllLevelsOption() *LogValueLevelOption {
+	return &LogValueLevelOption{all: true}
+}
+
+// WithLevel adds the given levels to the set
+func (o *LogValueLevelOption) WithLevel(levels ...Level) *LogValueLevelOption {
+	for _, level := range levels {
+		switch level {
+		case DebugLevel:
+			o.debug = true
+		case InfoLevel:
+			o.info = true
+		case ErrorLevel:
+			o.err = true
+		default:
+			if !o.hasCustomLevel(level) {
+				o.levels = append(o.levels, level)
+			}
+		}
+	}
+	return o
+}
+
+// WithAllLevels adds every level to the set
+func (o *LogValueLevelOption) WithAllLevels() *LogValueLevelOption {
+	o.all = true
+	return o
+}
+
+// AllLevels returns true if the set contains every level
+func (o LogValueLevelOption) AllLevels() bool {
+	return o.all
+}
+
+// Levels returns the levels of the set
+// It doesn't account for the all levels flag, refer to AllLevels
+func (o LogValueLevelOption) Levels() []Level {
+	levels := make([]Level, 0, 3+len(o.levels))
+	if o.debug {
+		levels = append(levels, DebugLevel)
+	}
+	if o.info {
+		levels = append(levels, InfoLevel)
+	}
+	if o.err {
+		levels = append(levels, ErrorLevel)
+	}
+	return append(levels, o.levels...)
+}
+
+// hasCustomLevel checks if the given custom level is already in the set
+func (o LogValueLevelOption) hasCustomLevel(level Level) bool {
+	for _, l := range o.levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
@@ -54,3 +54,38 @@ func TestNewLogValuesLevelOption(t *testing.T) {
 	assert.False(t, option.err)
 	assert.True(t, option.debug)
 }
+
+// Test that WithLevel maps built-in levels to their flags and keeps custom levels once
+func TestLogValueLevelOption_WithLevel(t *testing.T) {
+	const auditLevel Level = 12
+	option := NewLogValuesLevelOption(false, false, false).
+		WithLevel(DebugLevel, ErrorLevel, auditLevel, auditLevel)
+	assert.True(t, option.debug)
+	assert.True(t, option.err)
+	assert.False(t, option.info)
+	assert.Equal(t, []Level{DebugLevel, ErrorLevel, auditLevel}, option.Levels())
+}
+
+// Test that NewLogValuesAllLevelsOption creates an option for every level
+func TestNewLogValuesAllLevelsOption(t *testing.T) {
+	option := NewLogValuesAllLevelsOption()
+	assert.True(t, option.AllLevels())
+	assert.Empty(t, option.Levels())
+}
+
+// Test that flags set on a LogValueOption accumulate instead of replacing each other
+func TestLogValueOption_MultipleLevels(t *testing.T) {
+	option := NewLogValueOption("foo").WithDebug().WithInfo()
+	assert.Equal(t, []Level{DebugLevel, InfoLevel}, option.Levels())
+}
+
+// Test that NewLogValueOptionWithLevels attaches the attribute to the level option levels
+func TestNewLogValueOptionWithLevels(t *testing.T) {
+	option := NewLogValueOptionWithLevels("foo", NewLogValuesLevelOption(true, true, false)).
+		WithLevelOption(NewLogValuesAllLevelsOption())
+	assert.Equal(t, "foo", option.Attr)
+	assert.True(t, option.info)
+	assert.True(t, option.err)
+	assert.False(t, option.debug)
+	assert.True(t, option.AllLevels())
+}
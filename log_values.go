@@ -4,13 +4,71 @@ type DebugValues[T any] []T
 type ErrorValues[T any] []T
 type InfoValues[T any] []T
 
+// levelValues contains the values attached to a level
+type levelValues[T any] struct {
+	level Level
+	// values are the values evaluated when added
+	values []T
+	// lazy are the values evaluated on access
+	lazy []func() T
+}
+
+// len returns the number of values
+func (v levelValues[T]) len() int {
+	return len(v.values) + len(v.lazy)
+}
+
+// capped returns the values with their capacity limited to their length
+// so appending to them doesn't overwrite values shared with other log values
+func (v levelValues[T]) capped() levelValues[T] {
+	v.values = v.values[:len(v.values):len(v.values)]
+	v.lazy = v.lazy[:len(v.lazy):len(v.lazy)]
+	return v
+}
+
+// copied returns a copy of the values that doesn't share their backing arrays
+func (v levelValues[T]) copied() levelValues[T] {
+	return levelValues[T]{
+		level:  v.level,
+		values: append([]T(nil), v.values...),
+		lazy:   append([]func() T(nil), v.lazy...),
+	}
+}
+
+// mapped returns a copy of the values with the function applied to each of them
+func (v levelValues[T]) mapped(fn func(T) T) levelValues[T] {
+	values := make([]T, len(v.values))
+	for i, value := range v.values {
+		values[i] = fn(value)
+	}
+
+	lazy := make([]func() T, len(v.lazy))
+	for i, lazyValue := range v.lazy {
+		lazyValue := lazyValue
+		lazy[i] = func() T { return fn(lazyValue()) }
+	}
+	return levelValues[T]{level: v.level, values: values, lazy: lazy}
+}
+
+// appendTo appends the values evaluating the lazy ones
+func (v levelValues[T]) appendTo(dst []T) []T {
+	dst = append(dst, v.values...)
+	for _, fn := range v.lazy {
+		dst = append(dst, fn())
+	}
+	return dst
+}
+
 // LogValues is a wrapper for log values to be passed to the logger
 type LogValues[T any] struct {
-	msg         string
-	err         error
-	debugValues DebugValues[T]
-	errorValues ErrorValues[T]
-	infoValues  InfoValues[T]
+	msg string
+	err error
+	// levels contains the values attached to a single level
+	levels []levelValues[T]
+	// all contains the values attached to every level
+	all levelValues[T]
+	// route overrides the RoutingPolicy of the handler, unset when zero
+	route Route
 }
 
 // Msg returns the message
@@ -23,28 +81,103 @@ func (lv LogValues[T]) Err() error {
 	return lv.err
 }
 
+// Route returns the route overriding the RoutingPolicy of the handler
+// Returns zero when the log values don't override it
+func (lv LogValues[T]) Route() Route {
+	return lv.route
+}
+
+// Values returns the values to be logged at the given level,
+// the values attached to every level come first
+//
+// Lazy values are evaluated on every call,
+// adapters should only call it once the level is known to be enabled
+func (lv LogValues[T]) Values(level Level) []T {
+	levelValues := lv.level(level)
+	if len(lv.all.lazy) == 0 && len(levelValues.lazy) == 0 {
+		switch {
+		case len(lv.all.values) == 0:
+			return levelValues.values
+		case len(levelValues.values) == 0:
+			return lv.all.values
+		}
+	}
+	return lv.AppendValues(make([]T, 0, lv.all.len()+levelValues.len()), level)
+}
+
+// AppendValues appends the values to be logged at the given level to dst,
+// it allows adapters to reuse a buffer instead of allocating on every call
+func (lv LogValues[T]) AppendValues(dst []T, level Level) []T {
+	return lv.level(level).appendTo(lv.all.appendTo(dst))
+}
+
+// LevelValues returns only the values attached to the given level
+func (lv LogValues[T]) LevelValues(level Level) []T {
+	levelValues := lv.level(level)
+	if len(levelValues.lazy) == 0 {
+		return levelValues.values
+	}
+	return levelValues.appendTo(make([]T, 0, levelValues.len()))
+}
+
+// AllLevelsValues returns the values attached to every level
+func (lv LogValues[T]) AllLevelsValues() []T {
+	if len(lv.all.lazy) == 0 {
+		return lv.all.values
+	}
+	return lv.all.appendTo(make([]T, 0, lv.all.len()))
+}
+
+// Builder returns a builder initialized with the log values
+// It allows deriving modified log values without affecting the original ones
+func (lv LogValues[T]) Builder() *LogValuesBuilder[T] {
+	return &LogValuesBuilder[T]{
+		msg:    lv.msg,
+		err:    lv.err,
+		levels: lv.levels,
+		all:    lv.all.capped(),
+		route:  lv.route,
+		built:  true,
+		shared: true,
+	}
+}
+
 // DebugValues returns the debug values
 func (lv LogValues[T]) DebugValues() DebugValues[T] {
-	return lv.debugValues
+	return lv.Values(DebugLevel)
 }
 
 // ErrorValues returns the error values
 func (lv LogValues[T]) ErrorValues() ErrorValues[T] {
-	return lv.errorValues
+	return lv.Values(ErrorLevel)
 }
 
 // InfoValues returns the info values
 func (lv LogValues[T]) InfoValues() InfoValues[T] {
-	return lv.infoValues
+	return lv.Values(InfoLevel)
+}
+
+// level returns the values attached to the given level
+func (lv LogValues[T]) level(level Level) levelValues[T] {
+	for _, levelValues := range lv.levels {
+		if levelValues.level == level {
+			return levelValues
+		}
+	}
+	return levelValues[T]{level: level}
 }
 
 // LogValuesBuilder is a builder for log values
 type LogValuesBuilder[T any] struct {
-	msg         string
-	err         error
-	infoValues  []T
-	debugValues []T
-	errorValues []T
+	msg    string
+	err    error
+	levels []levelValues[T]
+	all    levelValues[T]
+	route  Route
+	// built is set when the levels are shared with built log values
+	built bool
+	// shared is set when the memory of the builder belongs to other log values, see LogValues.Builder
+	shared bool
 }
 
 // WithMsg sets the message
@@ -54,32 +187,136 @@ func (b *LogValuesBuilder[T]) WithMsg(msg string) *LogValuesBuilder[T] {
 }
 
 // WithErr sets the error
-func (b *LogValuesBuilder[T]) WithInfoValue(field T) *LogValuesBuilder[T] {
-	b.infoValues = append(b.infoValues, field)
+func (b *LogValuesBuilder[T]) WithErr(err error) *LogValuesBuilder[T] {
+	b.err = err
+	return b
+}
+
+// WithRoute overrides the RoutingPolicy of the handler for the built log values
+func (b *LogValuesBuilder[T]) WithRoute(route Route) *LogValuesBuilder[T] {
+	b.route = route
+	return b
+}
+
+// WithValue adds a value to the given level
+func (b *LogValuesBuilder[T]) WithValue(level Level, field T) *LogValuesBuilder[T] {
+	levelValues := b.level(level)
+	levelValues.values = append(levelValues.values, field)
+	return b
+}
+
+// WithAllLevelsValue adds a value to every level
+func (b *LogValuesBuilder[T]) WithAllLevelsValue(field T) *LogValuesBuilder[T] {
+	b.all.values = append(b.all.values, field)
+	return b
+}
+
+// WithLazyValue adds a value to the given level
+// The function is only evaluated when the level is logged, or when a FlightRecorder dumps the log
+func (b *LogValuesBuilder[T]) WithLazyValue(level Level, fn func() T) *LogValuesBuilder[T] {
+	levelValues := b.level(level)
+	levelValues.lazy = append(levelValues.lazy, fn)
 	return b
 }
 
+// WithLazyAllLevelsValue adds a value to every level
+// The function is only evaluated when a level is logged, or when a FlightRecorder dumps the log
+func (b *LogValuesBuilder[T]) WithLazyAllLevelsValue(fn func() T) *LogValuesBuilder[T] {
+	b.all.lazy = append(b.all.lazy, fn)
+	return b
+}
+
+// MapValues replaces every value with the result of the given function
+// Lazy values are mapped once evaluated
+func (b *LogValuesBuilder[T]) MapValues(fn func(T) T) *LogValuesBuilder[T] {
+	b.detach()
+	b.all = b.all.mapped(fn)
+	for i := range b.levels {
+		b.levels[i] = b.levels[i].mapped(fn)
+	}
+	return b
+}
+
+// WithInfoValue sets the info value
+func (b *LogValuesBuilder[T]) WithInfoValue(field T) *LogValuesBuilder[T] {
+	return b.WithValue(InfoLevel, field)
+}
+
 // WithDebugValue sets the debug value
 func (b *LogValuesBuilder[T]) WithDebugValue(field T) *LogValuesBuilder[T] {
-	b.debugValues = append(b.debugValues, field)
-	return b
+	return b.WithValue(DebugLevel, field)
 }
 
 // WithErrorValue sets the error value
 func (b *LogValuesBuilder[T]) WithErrorValue(field T) *LogValuesBuilder[T] {
-	b.errorValues = append(b.errorValues, field)
-	return b
+	return b.WithValue(ErrorLevel, field)
 }
 
 // Build builds the log values
+// Values added to the builder afterwards don't affect the built log values
 func (b *LogValuesBuilder[T]) Build() LogValues[T] {
+	b.built = true
 	return LogValues[T]{
-		msg:         b.msg,
-		err:         b.err,
-		infoValues:  b.infoValues,
-		debugValues: b.debugValues,
-		errorValues: b.errorValues,
+		msg:    b.msg,
+		err:    b.err,
+		levels: b.levels[:len(b.levels):len(b.levels)],
+		all:    b.all,
+		route:  b.route,
+	}
+}
+
+// Reset clears the builder keeping its memory to be reused
+// Log values built before must not be used afterwards,
+// the memory of builders derived with LogValues.Builder is dropped instead so the original log values are kept
+func (b *LogValuesBuilder[T]) Reset() {
+	if b.shared {
+		b.msg, b.err, b.route, b.built, b.shared = "", nil, 0, false, false
+		b.levels, b.all = nil, levelValues[T]{}
+		return
+	}
+	b.msg, b.err, b.route, b.built = "", nil, 0, false
+	b.all.reset()
+	for i := range b.levels {
+		b.levels[i].reset()
+	}
+}
+
+// reset clears the values keeping the capacity
+func (v *levelValues[T]) reset() {
+	var zero T
+	for i := range v.values {
+		v.values[i] = zero
+	}
+	for i := range v.lazy {
+		v.lazy[i] = nil
 	}
+	v.values, v.lazy = v.values[:0], v.lazy[:0]
+}
+
+// detach copies the levels when they are shared with built log values
+// capping the values so appending to them doesn't overwrite the shared ones
+func (b *LogValuesBuilder[T]) detach() {
+	if !b.built {
+		return
+	}
+	levels := make([]levelValues[T], len(b.levels), len(b.levels)+1)
+	for i, levelValues := range b.levels {
+		levels[i] = levelValues.capped()
+	}
+	b.levels, b.built = levels, false
+}
+
+// level returns the values attached to the given level to be modified
+// The levels are detached first when they are shared with built log values
+func (b *LogValuesBuilder[T]) level(level Level) *levelValues[T] {
+	b.detach()
+	for i := range b.levels {
+		if b.levels[i].level == level {
+			return &b.levels[i]
+		}
+	}
+	b.levels = append(b.levels, levelValues[T]{level: level})
+	return &b.levels[len(b.levels)-1]
 }
 
 // NewLogValuesBuilder creates a new log values builder
@@ -91,13 +328,12 @@ func NewLogValuesBuilder[T any]() *LogValuesBuilder[T] {
 func FactoryLogValuesBuilder[T any](options LogValuesOptions[T]) *LogValuesBuilder[T] {
 	builder := NewLogValuesBuilder[T]()
 	for _, option := range options {
-		switch {
-		case option.debug:
-			builder = builder.WithDebugValue(option.Attr)
-		case option.err:
-			builder = builder.WithErrorValue(option.Attr)
-		case option.info:
-			builder = builder.WithInfoValue(option.Attr)
+		if option.AllLevels() {
+			builder = builder.WithAllLevelsValue(option.Attr)
+			continue
+		}
+		for _, level := range option.Levels() {
+			builder = builder.WithValue(level, option.Attr)
 		}
 	}
 	return builder
@@ -0,0 +1,33 @@
+package observability
+
+import "sync"
+
+// LogValuesBuilderPool reuses LogValuesBuilders between log calls
+//
+// Log values built from a pooled builder share its memory,
+// Put must only be called once they are no longer used, e.g. after a synchronous log call returns.
+type LogValuesBuilderPool[T any] struct {
+	pool sync.Pool
+}
+
+// NewLogValuesBuilderPool creates a new LogValuesBuilderPool
+func NewLogValuesBuilderPool[T any]() *LogValuesBuilderPool[T] {
+	return &LogValuesBuilderPool[T]{
+		pool: sync.Pool{
+			New: func() any {
+				return NewLogValuesBuilder[T]()
+			},
+		},
+	}
+}
+
+// Get returns an empty builder from the pool
+func (p *LogValuesBuilderPool[T]) Get() *LogValuesBuilder[T] {
+	return p.pool.Get().(*LogValuesBuilder[T])
+}
+
+// Put resets the builder and returns it to the pool
+func (p *LogValuesBuilderPool[T]) Put(b *LogValuesBuilder[T]) {
+	b.Reset()
+	p.pool.Put(b)
+}
@@ -0,0 +1,42 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that Put resets the builder before returning it to the pool
+func TestLogValuesBuilderPool(t *testing.T) {
+	pool := NewLogValuesBuilderPool[string]()
+
+	b := pool.Get()
+	b.WithMsg("hello").WithInfoValue("foo")
+	pool.Put(b)
+
+	lv := pool.Get().Build()
+	assert.Empty(t, lv.Msg())
+	assert.Empty(t, lv.Values(InfoLevel))
+}
+
+func BenchmarkLogValuesBuilder(b *testing.B) {
+	b.Run("New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			lv := NewLogValuesBuilder[string]().WithMsg("hello").WithInfoValue("foo").WithAllLevelsValue("bar").Build()
+			_ = lv.Values(InfoLevel)
+		}
+	})
+
+	b.Run("Pooled", func(b *testing.B) {
+		pool := NewLogValuesBuilderPool[string]()
+		buf := make([]string, 0, 4)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			builder := pool.Get()
+			lv := builder.WithMsg("hello").WithInfoValue("foo").WithAllLevelsValue("bar").Build()
+			buf = lv.AppendValues(buf[:0], InfoLevel)
+			pool.Put(builder)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package observability
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -22,22 +23,46 @@ func TestLogValues_Err(t *testing.T) {
 
 // Test that DebugValues returns the debug values of the log values
 func TestLogValues_DebugValues(t *testing.T) {
-	lv := LogValues[string]{debugValues: []string{"foo", "bar"}}
+	lv := LogValues[string]{levels: []levelValues[string]{{level: DebugLevel, values: []string{"foo", "bar"}}}}
 	assert.EqualValues(t, []string{"foo", "bar"}, lv.DebugValues())
 }
 
 // Test that ErrorValues returns the error values of the log values
 func TestLogValues_ErrorValues(t *testing.T) {
-	lv := LogValues[string]{errorValues: []string{"baz", "qux"}}
+	lv := LogValues[string]{levels: []levelValues[string]{{level: ErrorLevel, values: []string{"baz", "qux"}}}}
 	assert.EqualValues(t, []string{"baz", "qux"}, lv.ErrorValues())
 }
 
 // Test that InfoValues returns the info values of the log values
 func TestLogValues_InfoValues(t *testing.T) {
-	lv := LogValues[string]{infoValues: []string{"corge", "grault"}}
+	lv := LogValues[string]{levels: []levelValues[string]{{level: InfoLevel, values: []string{"corge", "grault"}}}}
 	assert.EqualValues(t, []string{"corge", "grault"}, lv.InfoValues())
 }
 
+// Test that Values returns the values attached to every level before the level values
+func TestLogValues_Values(t *testing.T) {
+	lv := LogValues[string]{
+		levels: []levelValues[string]{{level: InfoLevel, values: []string{"foo"}}},
+		all:    levelValues[string]{values: []string{"tracing"}},
+	}
+	assert.Equal(t, []string{"tracing", "foo"}, lv.Values(InfoLevel))
+	assert.Equal(t, []string{"tracing"}, lv.Values(DebugLevel))
+	assert.Equal(t, []string{"foo"}, lv.LevelValues(InfoLevel))
+	assert.Equal(t, []string{"tracing"}, lv.AllLevelsValues())
+}
+
+// Test that custom levels can hold their own values
+func TestLogValues_CustomLevel(t *testing.T) {
+	const auditLevel Level = 12
+	lv := NewLogValuesBuilder[string]().
+		WithValue(auditLevel, "user").
+		WithInfoValue("foo").
+		Build()
+	assert.Equal(t, []string{"user"}, lv.Values(auditLevel))
+	assert.Equal(t, []string{"foo"}, lv.Values(InfoLevel))
+	assert.Empty(t, lv.Values(ErrorLevel))
+}
+
 // Test that WithMsg sets the message of the builder
 func TestLogValuesBuilder_WithMsg(t *testing.T) {
 	b := NewLogValuesBuilder[string]().WithMsg("hello")
@@ -47,19 +72,33 @@ func TestLogValuesBuilder_WithMsg(t *testing.T) {
 // Test that WithInfoValue appends an info value to the builder
 func TestLogValuesBuilder_WithInfoValue(t *testing.T) {
 	b := NewLogValuesBuilder[string]().WithInfoValue("foo")
-	assert.Equal(t, []string{"foo"}, b.infoValues)
+	assert.Equal(t, []string{"foo"}, b.level(InfoLevel).values)
 }
 
 // Test that WithDebugValue appends a debug value to the builder
 func TestLogValuesBuilder_WithDebugValue(t *testing.T) {
 	b := NewLogValuesBuilder[string]().WithDebugValue("bar")
-	assert.Equal(t, []string{"bar"}, b.debugValues)
+	assert.Equal(t, []string{"bar"}, b.level(DebugLevel).values)
 }
 
 // Test that WithErrorValue appends an error value to the builder
 func TestLogValuesBuilder_WithErrorValue(t *testing.T) {
 	b := NewLogValuesBuilder[string]().WithErrorValue("baz")
-	assert.Equal(t, []string{"baz"}, b.errorValues)
+	assert.Equal(t, []string{"baz"}, b.level(ErrorLevel).values)
+}
+
+// Test that WithAllLevelsValue appends a value to every level of the builder
+func TestLogValuesBuilder_WithAllLevelsValue(t *testing.T) {
+	b := NewLogValuesBuilder[string]().WithAllLevelsValue("qux")
+	assert.Equal(t, []string{"qux"}, b.all.values)
+}
+
+// Test that values added after Build don't affect the built log values
+func TestLogValuesBuilder_BuildIsolation(t *testing.T) {
+	b := NewLogValuesBuilder[string]().WithInfoValue("foo").WithAllLevelsValue("bar")
+	lv := b.Build()
+	b.WithInfoValue("baz").WithAllLevelsValue("qux")
+	assert.Equal(t, []string{"bar", "foo"}, lv.Values(InfoLevel))
 }
 
 // Test that Build creates a log values with the builder's fields
@@ -67,16 +106,18 @@ func TestLogValuesBuilder_Build(t *testing.T) {
 	b := NewLogValuesBuilder[string]()
 	b.msg = "hello"
 	b.err = errors.New("oops")
-	b.infoValues = []string{"corge"}
-	b.debugValues = []string{"grault"}
-	b.errorValues = []string{"garply"}
+	b.levels = []levelValues[string]{
+		{level: InfoLevel, values: []string{"corge"}},
+		{level: DebugLevel, values: []string{"grault"}},
+		{level: ErrorLevel, values: []string{"garply"}},
+	}
 
 	lv := b.Build()
 	assert.Equal(t, "hello", lv.msg)
 	assert.Equal(t, errors.New("oops"), lv.err)
-	assert.EqualValues(t, []string{"corge"}, lv.infoValues)
-	assert.EqualValues(t, []string{"grault"}, lv.debugValues)
-	assert.EqualValues(t, []string{"garply"}, lv.errorValues)
+	assert.EqualValues(t, []string{"corge"}, lv.level(InfoLevel).values)
+	assert.EqualValues(t, []string{"grault"}, lv.level(DebugLevel).values)
+	assert.EqualValues(t, []string{"garply"}, lv.level(ErrorLevel).values)
 }
 
 // Test that NewLogValuesBuilder creates a new empty builder
@@ -84,21 +125,125 @@ func TestNewLogValuesBuilder(t *testing.T) {
 	b := NewLogValuesBuilder[string]()
 	assert.Empty(t, b.msg)
 	assert.Nil(t, b.err)
-	assert.Empty(t, b.infoValues)
-	assert.Empty(t, b.debugValues)
-	assert.Empty(t, b.errorValues)
+	assert.Empty(t, b.levels)
+	assert.Empty(t, b.all.values)
 }
 
 // Test that FactorLogValuesBuilder creates a builder with the given options
 func TestFactoryLogValuesBuilder(t *testing.T) {
 	options := LogValuesOptions[string]{
-		{debug: true, Attr: "foo"},
-		{err: true, Attr: "bar"},
-		{info: true, Attr: "baz"},
+		{LogValueLevelOption: LogValueLevelOption{debug: true}, Attr: "foo"},
+		{LogValueLevelOption: LogValueLevelOption{err: true}, Attr: "bar"},
+		{LogValueLevelOption: LogValueLevelOption{info: true}, Attr: "baz"},
 	}
 
 	builder := FactoryLogValuesBuilder(options)
-	assert.Equal(t, []string{"foo"}, builder.debugValues)
-	assert.Equal(t, []string{"bar"}, builder.errorValues)
-	assert.Equal(t, []string{"baz"}, builder.infoValues)
+	assert.Equal(t, []string{"foo"}, builder.level(DebugLevel).values)
+	assert.Equal(t, []string{"bar"}, builder.level(ErrorLevel).values)
+	assert.Equal(t, []string{"baz"}, builder.level(InfoLevel).values)
+}
+
+// Test that FactoryLogValuesBuilder honours every level flag of an option
+func TestFactoryLogValuesBuilder_MultipleLevels(t *testing.T) {
+	const auditLevel Level = 12
+	options := LogValuesOptions[string]{
+		NewLogValueOption("foo").WithDebug().WithInfo(),
+		NewLogValueOption("bar").WithAllLevels(),
+		NewLogValueOption("baz").WithLevel(auditLevel),
+	}
+
+	lv := FactoryLogValuesBuilder(options).Build()
+	assert.Equal(t, []string{"bar", "foo"}, lv.Values(DebugLevel))
+	assert.Equal(t, []string{"bar", "foo"}, lv.Values(InfoLevel))
+	assert.Equal(t, []string{"bar"}, lv.Values(ErrorLevel))
+	assert.Equal(t, []string{"bar", "baz"}, lv.Values(auditLevel))
+}
+
+// Test that lazy values are only evaluated when the values are accessed
+func TestLogValuesBuilder_WithLazyValue(t *testing.T) {
+	calls := 0
+	lazy := func() string {
+		calls++
+		return "lazy"
+	}
+
+	lv := NewLogValuesBuilder[string]().
+		WithAllLevelsValue("tracing").
+		WithLazyAllLevelsValue(func() string { return "all" }).
+		WithDebugValue("foo").
+		WithLazyValue(DebugLevel, lazy).
+		Build()
+	assert.Equal(t, 0, calls)
+
+	assert.Equal(t, []string{"tracing", "all"}, lv.Values(InfoLevel))
+	assert.Equal(t, 0, calls)
+
+	assert.Equal(t, []string{"tracing", "all", "foo", "lazy"}, lv.Values(DebugLevel))
+	assert.Equal(t, []string{"foo", "lazy"}, lv.LevelValues(DebugLevel))
+	assert.Equal(t, []string{"tracing", "all"}, lv.AllLevelsValues())
+	assert.Equal(t, 2, calls)
+}
+
+// Test that Reset clears the builder keeping its memory
+func TestLogValuesBuilder_Reset(t *testing.T) {
+	b := NewLogValuesBuilder[string]().
+		WithMsg("hello").
+		WithInfoValue("foo").
+		WithAllLevelsValue("bar")
+	b.Reset()
+
+	lv := b.Build()
+	assert.Empty(t, lv.Msg())
+	assert.Empty(t, lv.Values(InfoLevel))
+	assert.Equal(t, 1, cap(b.all.values))
+}
+
+// Test that Reset on a derived builder leaves the original log values intact
+func TestLogValuesBuilder_ResetDerived(t *testing.T) {
+	lv := NewLogValuesBuilder[string]().
+		WithMsg("hello").
+		WithInfoValue("foo").
+		WithAllLevelsValue("bar").
+		Build()
+
+	b := lv.Builder()
+	b.Reset()
+	b.WithInfoValue("baz")
+
+	assert.Equal(t, "hello", lv.Msg())
+	assert.Equal(t, []string{"bar", "foo"}, lv.Values(InfoLevel))
+	assert.Equal(t, []string{"baz"}, b.Build().Values(InfoLevel))
+}
+
+// Test that AppendValues reuses the given buffer
+func TestLogValues_AppendValues(t *testing.T) {
+	lv := NewLogValuesBuilder[string]().
+		WithAllLevelsValue("tracing").
+		WithInfoValue("foo").
+		Build()
+
+	buf := make([]string, 0, 4)
+	values := lv.AppendValues(buf, InfoLevel)
+	assert.Equal(t, []string{"tracing", "foo"}, values)
+	assert.Equal(t, &buf[:1][0], &values[0])
+}
+
+// Test that MapValues maps every value without affecting the original log values
+func TestLogValuesBuilder_MapValues(t *testing.T) {
+	lv := NewLogValuesBuilder[string]().
+		WithAllLevelsValue("password=secret").
+		WithInfoValue("user=alice").
+		WithLazyValue(InfoLevel, func() string { return "password=lazy" }).
+		Build()
+
+	redact := func(value string) string {
+		if strings.HasPrefix(value, "password=") {
+			return "password=[REDACTED]"
+		}
+		return value
+	}
+	redacted := lv.Builder().MapValues(redact).Build()
+
+	assert.Equal(t, []string{"password=[REDACTED]", "user=alice", "password=[REDACTED]"}, redacted.Values(InfoLevel))
+	assert.Equal(t, []string{"password=secret", "user=alice", "password=lazy"}, lv.Values(InfoLevel))
 }
@@ -0,0 +1,41 @@
+package zap
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// AttributeField converts an attribute into a zap field with the same key and typed value
+// so the semantic convention helpers land with the same key in logs and spans
+func AttributeField(kv attribute.KeyValue) zap.Field {
+	key := string(kv.Key)
+	switch kv.Value.Type() {
+	case attribute.BOOL:
+		return zap.Bool(key, kv.Value.AsBool())
+	case attribute.INT64:
+		return zap.Int64(key, kv.Value.AsInt64())
+	case attribute.FLOAT64:
+		return zap.Float64(key, kv.Value.AsFloat64())
+	case attribute.STRING:
+		return zap.String(key, kv.Value.AsString())
+	case attribute.BOOLSLICE:
+		return zap.Bools(key, kv.Value.AsBoolSlice())
+	case attribute.INT64SLICE:
+		return zap.Int64s(key, kv.Value.AsInt64Slice())
+	case attribute.FLOAT64SLICE:
+		return zap.Float64s(key, kv.Value.AsFloat64Slice())
+	case attribute.STRINGSLICE:
+		return zap.Strings(key, kv.Value.AsStringSlice())
+	default:
+		return zap.Any(key, kv.Value.AsInterface())
+	}
+}
+
+// AttributeFields converts the attributes into zap fields, refer to AttributeField
+func AttributeFields(kvs ...attribute.KeyValue) []zap.Field {
+	fields := make([]zap.Field, len(kvs))
+	for i, kv := range kvs {
+		fields[i] = AttributeField(kv)
+	}
+	return fields
+}
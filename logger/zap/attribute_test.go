@@ -0,0 +1,30 @@
+package zap
+
+import (
+	"testing"
+
+	"github.com/sosalejandro/observability"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+func TestAttributeField(t *testing.T) {
+	assert.Equal(t, zap.String("http.request.method", "GET"), AttributeField(observability.HTTPRequestMethod("GET")))
+	assert.Equal(t, zap.Int64("http.response.status_code", 200), AttributeField(observability.HTTPResponseStatusCode(200)))
+	assert.Equal(t, zap.Bool("cache.hit", true), AttributeField(attribute.Bool("cache.hit", true)))
+	assert.Equal(t, zap.Float64("ratio", 0.5), AttributeField(attribute.Float64("ratio", 0.5)))
+	assert.Equal(t, zap.Strings("tags", []string{"a", "b"}), AttributeField(attribute.StringSlice("tags", []string{"a", "b"})))
+}
+
+func TestAttributeFields_LogsAndSpans(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	kvs := []attribute.KeyValue{observability.DBSystem("postgresql"), observability.DBOperation("SELECT")}
+
+	logger.Info("query", AttributeFields(kvs...)...)
+	// Assert the span attribute keys are used in the log entry
+	assert.Equal(t, map[string]interface{}{
+		"db.system":    "postgresql",
+		"db.operation": "SELECT",
+	}, logs.All()[0].ContextMap())
+}
@@ -0,0 +1,131 @@
+package zap
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/sosalejandro/observability"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newBenchmarkLogger creates a zap logger encoding JSON into io.Discard at the debug level
+func newBenchmarkLogger() *zap.Logger {
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(encoder, zapcore.AddSync(io.Discard), zapcore.DebugLevel))
+}
+
+var benchmarkFields = []zap.Field{
+	zap.String("foo", "bar"),
+	zap.Int("count", 42),
+}
+
+func benchmarkLogValues() observability.LogValues[zap.Field] {
+	b := observability.NewLogValuesBuilder[zap.Field]().WithMsg("benchmark message")
+	for _, field := range benchmarkFields {
+		b.WithInfoValue(field).WithDebugValue(field).WithErrorValue(field)
+	}
+	return b.Build()
+}
+
+func BenchmarkRawZap(b *testing.B) {
+	logger := newBenchmarkLogger()
+	levels := []struct {
+		name string
+		log  func(string, ...zap.Field)
+	}{
+		{"Info", logger.Info},
+		{"Debug", logger.Debug},
+		{"Error", logger.Error},
+	}
+	for _, level := range levels {
+		log := level.log
+		b.Run(level.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				log("benchmark message", benchmarkFields...)
+			}
+		})
+	}
+}
+
+func BenchmarkZapLogger(b *testing.B) {
+	logger := NewZapLogger(newBenchmarkLogger())
+	lv := benchmarkLogValues()
+	levels := []struct {
+		name string
+		log  func(observability.LogValues[zap.Field])
+	}{
+		{"Info", logger.LogInfo},
+		{"Debug", logger.LogDebug},
+		{"Error", logger.LogError},
+	}
+	for _, level := range levels {
+		log := level.log
+		b.Run(level.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				log(lv)
+			}
+		})
+	}
+}
+
+func BenchmarkObservabilityContext(b *testing.B) {
+	h := NewZapHandler(context.Background(), "benchmark", newBenchmarkLogger())
+	_, end := h.StartSpan("benchmark")
+	defer end()
+
+	lv := benchmarkLogValues()
+	levels := []struct {
+		name string
+		log  func(observability.LogValues[zap.Field], ...trace.EventOption)
+	}{
+		{"Info", h.LogInfo},
+		{"Debug", h.LogDebug},
+		{"Error", h.LogError},
+	}
+	for _, level := range levels {
+		log := level.log
+		b.Run(level.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				log(lv)
+			}
+		})
+	}
+}
+
+func BenchmarkObservabilityContext_PooledBuilder(b *testing.B) {
+	h := NewZapHandler(context.Background(), "benchmark", newBenchmarkLogger())
+	_, end := h.StartSpan("benchmark")
+	defer end()
+
+	lb := h.CreateLogBuilder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		builder := lb.AcquireLogValuesBuilder().WithMsg("benchmark message")
+		for _, field := range benchmarkFields {
+			builder.WithInfoValue(field)
+		}
+		h.LogInfo(builder.Build())
+		lb.ReleaseLogValuesBuilder(builder)
+	}
+}
+
+func BenchmarkObservabilityContext_Disabled(b *testing.B) {
+	atomicLevel := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	logger := zap.New(zapcore.NewCore(encoder, zapcore.AddSync(io.Discard), atomicLevel))
+	h, _ := NewZapHandlerWithLevel(context.Background(), "benchmark", logger, atomicLevel)
+	_, end := h.StartSpan("benchmark")
+	defer end()
+
+	lv := benchmarkLogValues()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h.LogDebug(lv)
+	}
+}
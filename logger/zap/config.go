@@ -0,0 +1,101 @@
+package zap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sosalejandro/observability"
+	"github.com/sosalejandro/observability/config"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ConfiguredHandler is a handler built from a config.Config
+// along with the resources it created
+type ConfiguredHandler struct {
+	observability.ObservabilityHandler[zap.Field]
+	// Logger is the zap logger the handler writes to
+	Logger *zap.Logger
+	// Levels changes the levels of the handler at runtime
+	Levels *observability.LevelController
+	// TracerProvider starts the spans of the handler, nil when tracing is disabled
+	TracerProvider *sdktrace.TracerProvider
+
+	output      string
+	closeOutput func()
+}
+
+// NewHandlerFromConfig builds a handler from the given configuration
+//
+// The zap logger writes to the configured output in the configured format, its level is
+// driven by a LevelController, spans are started by the configured tracer provider
+// and the configured keys are redacted. Returns the configuration errors pointing to the offending keys.
+func NewHandlerFromConfig(ctx context.Context, cfg config.Config, opts ...observability.ObservabilityOption[zap.Field]) (*ConfiguredHandler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Log.Backend != "zap" {
+		return nil, &config.Error{Key: "log.backend", Err: fmt.Errorf("backend %q isn't supported by the zap adapter", cfg.Log.Backend)}
+	}
+
+	level, err := cfg.Log.ParseLevel()
+	if err != nil {
+		return nil, &config.Error{Key: "log.level", Err: err}
+	}
+	atomicLevel := zap.NewAtomicLevelAt(ZapLevel(level))
+
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	if cfg.Log.Format == "console" {
+		encoder = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	}
+
+	sink, closeOutput, err := zap.Open(cfg.Log.Output)
+	if err != nil {
+		return nil, &config.Error{Key: "log.output", Err: err}
+	}
+	logger := zap.New(zapcore.NewCore(encoder, sink, atomicLevel))
+
+	tp, err := cfg.NewTracerProvider()
+	if err != nil {
+		closeOutput()
+		return nil, err
+	}
+
+	handlerOpts := make([]observability.ObservabilityOption[zap.Field], 0, len(opts)+2)
+	if tp != nil {
+		handlerOpts = append(handlerOpts, observability.WithTracerProvider[zap.Field](tp))
+	}
+	if len(cfg.Redaction.Keys) > 0 {
+		handlerOpts = append(handlerOpts, observability.WithLogHooks[zap.Field](RedactionHook(cfg.Redaction)))
+	}
+	handlerOpts = append(handlerOpts, opts...)
+
+	h, levels := NewZapHandlerWithLevel(ctx, cfg.ServiceName, logger, atomicLevel, handlerOpts...)
+	// The controller starts at the configured level rather than the closest zap level
+	levels.SetLevel(level)
+
+	return &ConfiguredHandler{
+		ObservabilityHandler: h,
+		Logger:               logger,
+		Levels:               levels,
+		TracerProvider:       tp,
+		output:               cfg.Log.Output,
+		closeOutput:          closeOutput,
+	}, nil
+}
+
+// Shutdown flushes the spans and the logs and closes the output
+func (h *ConfiguredHandler) Shutdown(ctx context.Context) error {
+	var errs []error
+	if h.TracerProvider != nil {
+		errs = append(errs, h.TracerProvider.Shutdown(ctx))
+	}
+	// Syncing the standard streams fails on some platforms
+	if err := h.Logger.Sync(); err != nil && h.output != "stdout" && h.output != "stderr" {
+		errs = append(errs, err)
+	}
+	h.closeOutput()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package zap
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sosalejandro/observability"
+	"github.com/sosalejandro/observability/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNewHandlerFromConfig(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "app.log")
+	cfg, err := config.Parse([]byte(`
+serviceName: checkout
+log:
+  level: debug
+  output: `+output+`
+tracing:
+  enabled: true
+  sampler: always_on
+redaction:
+  keys: [password]
+`), "yaml")
+	assert.NoError(t, err)
+
+	h, err := NewHandlerFromConfig(context.Background(), cfg)
+	assert.NoError(t, err)
+	_, end := h.StartSpan("GET /orders")
+
+	// Assert the span is started by the configured tracer provider
+	traceValues, err := h.GetTraceValues()
+	assert.NoError(t, err)
+	assert.NotEqual(t, "00000000000000000000000000000000", traceValues.TraceId)
+
+	h.LogDebug(observability.NewLogValuesBuilder[zap.Field]().
+		WithMsg("login").
+		WithDebugValue(zap.String("user", "alice")).
+		WithDebugValue(zap.String("password", "secret")).
+		Build())
+	end()
+	assert.Equal(t, observability.DebugLevel, h.Levels.Level())
+	assert.NoError(t, h.Shutdown(context.Background()))
+
+	logs, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Contains(t, string(logs), `"user":"alice"`)
+	assert.Contains(t, string(logs), `"password":"[REDACTED]"`)
+	assert.NotContains(t, string(logs), "secret")
+}
+
+func TestNewHandlerFromConfig_Errors(t *testing.T) {
+	cfg := config.Default()
+	cfg.ServiceName = "checkout"
+	cfg.Log.Backend = "slog"
+
+	_, err := NewHandlerFromConfig(context.Background(), cfg)
+	var configErr *config.Error
+	assert.True(t, errors.As(err, &configErr))
+	assert.Equal(t, "log.backend", configErr.Key)
+
+	cfg.Log.Backend = "zap"
+	cfg.Log.Output = filepath.Join(t.TempDir(), "missing", "app.log")
+	_, err = NewHandlerFromConfig(context.Background(), cfg)
+	assert.True(t, errors.As(err, &configErr))
+	assert.Equal(t, "log.output", configErr.Key)
+}
@@ -0,0 +1,70 @@
+package zap
+
+import (
+	"context"
+
+	"github.com/sosalejandro/observability"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ConvertField converts a neutral field into a zap field with the same key and typed value
+// Groups are converted to zap objects, inlined when their key is empty
+func ConvertField(f observability.Field) zap.Field {
+	switch f.Kind {
+	case observability.StringKind:
+		return zap.String(f.Key, f.StringValue())
+	case observability.IntKind:
+		return zap.Int64(f.Key, f.IntValue())
+	case observability.FloatKind:
+		return zap.Float64(f.Key, f.FloatValue())
+	case observability.BoolKind:
+		return zap.Bool(f.Key, f.BoolValue())
+	case observability.DurationKind:
+		return zap.Duration(f.Key, f.DurationValue())
+	case observability.TimeKind:
+		return zap.Time(f.Key, f.TimeValue())
+	case observability.ErrorKind:
+		return zap.NamedError(f.Key, f.ErrorValue())
+	case observability.GroupKind:
+		if f.Key == "" {
+			return zap.Inline(group(f.GroupValue()))
+		}
+		return zap.Object(f.Key, group(f.GroupValue()))
+	default:
+		return zap.Any(f.Key, f.Value())
+	}
+}
+
+// ConvertFields converts neutral fields into zap fields, refer to ConvertField
+func ConvertFields(fields ...observability.Field) []zap.Field {
+	converted := make([]zap.Field, len(fields))
+	for i, f := range fields {
+		converted[i] = ConvertField(f)
+	}
+	return converted
+}
+
+// group marshals the fields of a group as the keys of a zap object
+type group []observability.Field
+
+// MarshalLogObject adds every field to the encoder
+func (g group) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range g {
+		ConvertField(f).AddTo(enc)
+	}
+	return nil
+}
+
+// NewFieldLogger creates a logger of neutral fields writing to the given zap logger
+func NewFieldLogger(zapLogger *zap.Logger) *observability.FieldLogger[zap.Field] {
+	return observability.NewFieldLogger[zap.Field](NewZapLogger(zapLogger), ConvertField)
+}
+
+// NewFieldHandler creates a handler of neutral fields logging with the given zap logger,
+// so libraries logging observability.Field can write to zap
+// Keys and values are converted with observability.Any unless another ValueConverter is given
+func NewFieldHandler(ctx context.Context, serviceName string, zapLogger *zap.Logger, opts ...observability.ObservabilityOption[observability.Field]) observability.ObservabilityHandler[observability.Field] {
+	opts = append([]observability.ObservabilityOption[observability.Field]{observability.WithValueConverter[observability.Field](observability.Any)}, opts...)
+	return observability.NewObservabilityHandler[observability.Field](ctx, serviceName, NewFieldLogger(zapLogger), opts...)
+}
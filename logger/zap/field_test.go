@@ -0,0 +1,68 @@
+package zap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sosalejandro/observability"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+func TestConvertField(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	err := errors.New("boom")
+
+	logger.Info("fields", ConvertFields(
+		observability.String("name", "checkout"),
+		observability.Int("items", 3),
+		observability.Float64("ratio", 0.5),
+		observability.Bool("retried", true),
+		observability.Duration("elapsed", time.Second),
+		observability.Err(err),
+		observability.Object("ids", []int{1, 2}),
+		observability.GroupOf("http", observability.String("method", "GET")),
+		observability.GroupOf("", observability.String("inlined", "yes")),
+	)...)
+
+	assert.Equal(t, map[string]interface{}{
+		"name":    "checkout",
+		"items":   int64(3),
+		"ratio":   0.5,
+		"retried": true,
+		"elapsed": time.Second,
+		"error":   "boom",
+		"ids":     []interface{}{1, 2},
+		"http":    map[string]interface{}{"method": "GET"},
+		"inlined": "yes",
+	}, logs.All()[0].ContextMap())
+}
+
+func TestNewFieldHandler(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	h := NewFieldHandler(context.Background(), "checkout", logger,
+		observability.WithTracerProvider[observability.Field](sdktrace.NewTracerProvider()))
+	assert.NoError(t, h.SetTracingFormat(observability.FieldTracingFormat(observability.OTelTracingFormatter())))
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+	tv, _ := h.GetTraceValues()
+
+	h.LogInfo(h.CreateLogBuilder().CreateLogValuesBuilder().
+		WithMsg("order").
+		WithInfoValue(observability.String("id", "42")).
+		Build())
+	h.Infow("charging card", "amount", 9.99)
+
+	entries := logs.All()
+	assert.Equal(t, map[string]interface{}{
+		"trace_id":    tv.TraceId,
+		"span_id":     tv.SpanId,
+		"trace_flags": "01",
+		"id":          "42",
+	}, entries[0].ContextMap())
+	assert.Equal(t, 9.99, entries[1].ContextMap()["amount"])
+	assert.Equal(t, zap.InfoLevel, entries[1].Level)
+}
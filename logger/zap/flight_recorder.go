@@ -0,0 +1,27 @@
+package zap
+
+import (
+	"github.com/sosalejandro/observability"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewFlightRecorder creates a FlightRecorder keeping the last size logs
+// and writing the zap fields of the records as JSON objects with FieldsObject
+// The records dumped to a logger carry their time, level and ids as zap.Any fields
+func NewFlightRecorder(size int, opts ...observability.FlightRecorderOption[zap.Field]) *observability.FlightRecorder[zap.Field] {
+	opts = append([]observability.FlightRecorderOption[zap.Field]{
+		observability.WithRecordValuesEncoder[zap.Field](FieldsObject),
+		observability.WithRecordValueConverter[zap.Field](zap.Any),
+	}, opts...)
+	return observability.NewFlightRecorder(size, opts...)
+}
+
+// FieldsObject encodes the fields as a map of their keys to their values
+func FieldsObject(fields []zap.Field) any {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	return enc.Fields
+}
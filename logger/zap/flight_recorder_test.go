@@ -0,0 +1,70 @@
+package zap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sosalejandro/observability"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+func TestNewFlightRecorder(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	var dump bytes.Buffer
+	recorder := NewFlightRecorder(10, observability.WithDumpOnError[zap.Field](func(r *observability.FlightRecorder[zap.Field]) {
+		_, _ = r.WriteTo(&dump)
+	}))
+	h := NewZapHandler(context.Background(), "checkout", logger,
+		observability.WithFlightRecorder[zap.Field](recorder),
+		observability.WithLevelController[zap.Field](observability.NewLevelController(observability.InfoLevel)))
+
+	h.LogDebug(observability.NewLogValuesBuilder[zap.Field]().
+		WithMsg("loading cart").
+		WithDebugValue(zap.Int("items", 3)).
+		WithDebugValue(zap.Any("cart", map[string]string{"id": "c-1"})).
+		Build())
+	assert.Empty(t, logs.All())
+	assert.Zero(t, dump.Len())
+
+	h.LogError(observability.NewLogValuesBuilder[zap.Field]().WithMsg("card declined").Build())
+	assert.Len(t, logs.All(), 1)
+
+	var first map[string]interface{}
+	assert.NoError(t, json.NewDecoder(&dump).Decode(&first))
+	assert.Equal(t, "loading cart", first["msg"])
+	assert.Equal(t, map[string]interface{}{
+		"items": float64(3),
+		"cart":  map[string]interface{}{"id": "c-1"},
+	}, first["values"])
+}
+
+func TestNewFlightRecorder_DumpTo(t *testing.T) {
+	dumpLogger, dumped := setupLogsCapture()
+	recorder := NewFlightRecorder(10)
+	h := NewZapHandler(context.Background(), "checkout", zap.NewNop(),
+		observability.WithFlightRecorder[zap.Field](recorder),
+		observability.WithTracerProvider[zap.Field](sdktrace.NewTracerProvider()))
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+	tv, _ := h.GetTraceValues()
+
+	h.LogDebugContext(observability.NewLogValuesBuilder[zap.Field]().WithMsg("loading cart").WithDebugValue(zap.Int("items", 3)).Build())
+	recorder.DumpTo(NewZapLogger(dumpLogger), observability.InfoLevel)
+
+	assert.Len(t, dumped.All(), 1)
+	assert.Equal(t, zap.InfoLevel, dumped.All()[0].Level)
+	fields := dumped.All()[0].ContextMap()
+	assert.True(t, recorder.Records()[0].Time.Equal(fields[observability.RecordTimeKey].(time.Time)))
+	delete(fields, observability.RecordTimeKey)
+	assert.Equal(t, map[string]interface{}{
+		observability.RecordLevelKey:   "debug",
+		observability.RecordTraceIdKey: tv.TraceId,
+		observability.RecordSpanIdKey:  tv.SpanId,
+		"items":                        int64(3),
+	}, fields)
+}
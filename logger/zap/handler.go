@@ -7,7 +7,18 @@ import (
 	"go.uber.org/zap"
 )
 
-func NewZapHandler(ctx context.Context, serviceName string, zapLogger *zap.Logger) observability.ObservabilityHandler[zap.Field] {
+// NewZapHandler creates a handler logging with the given zap logger
+// Keys and values, e.g. the durations of the timers, are converted with zap.Any unless another ValueConverter is given
+func NewZapHandler(ctx context.Context, serviceName string, zapLogger *zap.Logger, opts ...observability.ObservabilityOption[zap.Field]) observability.ObservabilityHandler[zap.Field] {
 	logger := NewZapLogger(zapLogger)
-	return observability.NewObservabilityHandler[zap.Field](ctx, serviceName, logger)
+	opts = append([]observability.ObservabilityOption[zap.Field]{observability.WithValueConverter[zap.Field](zap.Any)}, opts...)
+	return observability.NewObservabilityHandler[zap.Field](ctx, serviceName, logger, opts...)
+}
+
+// NewZapHandlerWithLevel creates a handler whose levels can be changed at runtime
+// The zap logger must be built on the given atomic level
+func NewZapHandlerWithLevel(ctx context.Context, serviceName string, zapLogger *zap.Logger, atomicLevel zap.AtomicLevel, opts ...observability.ObservabilityOption[zap.Field]) (observability.ObservabilityHandler[zap.Field], *observability.LevelController) {
+	controller := NewLevelController(atomicLevel)
+	opts = append([]observability.ObservabilityOption[zap.Field]{observability.WithLevelController[zap.Field](controller)}, opts...)
+	return NewZapHandler(ctx, serviceName, zapLogger, opts...), controller
 }
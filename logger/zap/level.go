@@ -0,0 +1,54 @@
+package zap
+
+import (
+	"github.com/sosalejandro/observability"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ZapLevel converts an observability level into the closest zap level
+func ZapLevel(level observability.Level) zapcore.Level {
+	switch {
+	case level < observability.InfoLevel:
+		return zapcore.DebugLevel
+	case level < observability.WarnLevel:
+		return zapcore.InfoLevel
+	case level < observability.ErrorLevel:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// ObservabilityLevel converts a zap level into the closest observability level
+func ObservabilityLevel(level zapcore.Level) observability.Level {
+	switch {
+	case level < zapcore.InfoLevel:
+		return observability.DebugLevel
+	case level < zapcore.WarnLevel:
+		return observability.InfoLevel
+	case level < zapcore.ErrorLevel:
+		return observability.WarnLevel
+	default:
+		return observability.ErrorLevel
+	}
+}
+
+// NewLevelController creates a LevelController starting at the atomic level
+// and keeps the atomic level in sync with it, refer to BindAtomicLevel
+func NewLevelController(atomicLevel zap.AtomicLevel) *observability.LevelController {
+	controller := observability.NewLevelController(ObservabilityLevel(atomicLevel.Level()))
+	BindAtomicLevel(controller, atomicLevel)
+	return controller
+}
+
+// BindAtomicLevel keeps the atomic level at the lowest level enabled by the controller
+//
+// The zap core lets through every level any override may need,
+// the handler consults the controller to filter the rest.
+func BindAtomicLevel(controller *observability.LevelController, atomicLevel zap.AtomicLevel) {
+	atomicLevel.SetLevel(ZapLevel(controller.MinLevel()))
+	controller.OnChange(func(level observability.Level) {
+		atomicLevel.SetLevel(ZapLevel(level))
+	})
+}
@@ -0,0 +1,54 @@
+package zap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sosalejandro/observability"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestZapLevel(t *testing.T) {
+	assert.Equal(t, zapcore.DebugLevel, ZapLevel(observability.DebugLevel))
+	assert.Equal(t, zapcore.InfoLevel, ZapLevel(observability.InfoLevel))
+	assert.Equal(t, zapcore.WarnLevel, ZapLevel(observability.WarnLevel))
+	assert.Equal(t, zapcore.ErrorLevel, ZapLevel(observability.ErrorLevel))
+	assert.Equal(t, zapcore.DebugLevel, ZapLevel(observability.Level(-8)))
+	assert.Equal(t, observability.InfoLevel, ObservabilityLevel(zapcore.InfoLevel))
+	assert.Equal(t, observability.WarnLevel, ObservabilityLevel(zapcore.WarnLevel))
+}
+
+func TestBindAtomicLevel(t *testing.T) {
+	atomicLevel := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	controller := NewLevelController(atomicLevel)
+	assert.Equal(t, observability.InfoLevel, controller.Level())
+
+	// Assert overrides lower the atomic level so the core lets them through
+	controller.SetSpanLevel("GET /orders", observability.DebugLevel)
+	assert.Equal(t, zapcore.DebugLevel, atomicLevel.Level())
+
+	controller.ClearSpanLevel("GET /orders")
+	controller.SetLevel(observability.ErrorLevel)
+	assert.Equal(t, zapcore.ErrorLevel, atomicLevel.Level())
+}
+
+func TestNewZapHandlerWithLevel(t *testing.T) {
+	atomicLevel := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	core, logs := observer.New(atomicLevel)
+	h, controller := NewZapHandlerWithLevel(context.Background(), "checkout", zap.New(core), atomicLevel)
+	_, end := h.StartSpan("GET /orders")
+	defer end()
+
+	lv := observability.NewLogValuesBuilder[zap.Field]().WithMsg("test message").Build()
+	h.LogDebug(lv)
+	assert.Equal(t, 0, logs.Len())
+
+	// Assert debug logs are emitted after changing the level at runtime
+	controller.SetLevel(observability.DebugLevel)
+	h.LogDebug(lv)
+	assert.Equal(t, 1, logs.Len())
+	assert.Equal(t, zap.DebugLevel, logs.All()[0].Level)
+}
@@ -0,0 +1,46 @@
+package zap
+
+import (
+	"github.com/sosalejandro/observability"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewLimiter creates a Limiter truncating the zap fields with TruncateField
+// and marking the dropped values with DroppedValuesField unless the limits set their own
+func NewLimiter(limits observability.Limits[zap.Field]) *observability.Limiter[zap.Field] {
+	if limits.TruncateValue == nil {
+		limits.TruncateValue = TruncateField
+	}
+	if limits.DroppedValuesMarker == nil {
+		limits.DroppedValuesMarker = DroppedValuesField
+	}
+	return observability.NewLimiter(limits)
+}
+
+// TruncateField truncates the string and binary fields to the given size
+// Other fields are returned as is
+func TruncateField(field zap.Field, size int) (zap.Field, bool) {
+	switch field.Type {
+	case zapcore.StringType:
+		value, truncated := observability.TruncateString(field.String, size)
+		if truncated {
+			return zap.String(field.Key, value), true
+		}
+	case zapcore.ByteStringType:
+		if value, ok := field.Interface.([]byte); ok && len(value) > size {
+			truncated, _ := observability.TruncateString(string(value), size)
+			return zap.ByteString(field.Key, []byte(truncated)), true
+		}
+	case zapcore.BinaryType:
+		if value, ok := field.Interface.([]byte); ok && len(value) > size {
+			return zap.Binary(field.Key, value[:size]), true
+		}
+	}
+	return field, false
+}
+
+// DroppedValuesField is the field marking the number of values dropped by the limits
+func DroppedValuesField(dropped int) zap.Field {
+	return zap.Int("dropped_values", dropped)
+}
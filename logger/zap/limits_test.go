@@ -0,0 +1,47 @@
+package zap
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sosalejandro/observability"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTruncateField(t *testing.T) {
+	field, truncated := TruncateField(zap.String("body", "hello world"), 5)
+	assert.True(t, truncated)
+	assert.Equal(t, zap.String("body", "hello"+observability.TruncationMarker), field)
+
+	field, truncated = TruncateField(zap.Binary("payload", []byte{1, 2, 3}), 2)
+	assert.True(t, truncated)
+	assert.Equal(t, zap.Binary("payload", []byte{1, 2}), field)
+
+	field, truncated = TruncateField(zap.Int("count", 123456), 2)
+	assert.False(t, truncated)
+	assert.Equal(t, zap.Int("count", 123456), field)
+}
+
+func TestNewLimiter(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	limiter := NewLimiter(observability.Limits[zap.Field]{MaxValuesPerLevel: 1, MaxValueSize: 3})
+	h := NewZapHandler(context.Background(), "checkout", logger, observability.WithLimiter[zap.Field](limiter))
+	_, end := h.StartSpan("GET /orders")
+	defer end()
+
+	h.LogInfo(observability.NewLogValuesBuilder[zap.Field]().
+		WithMsg("order").
+		WithAllLevelsValue(zap.String("body", strings.Repeat("a", 10))).
+		WithInfoValue(zap.String("extra", "b")).
+		WithDebugValue(zap.String("query", "c")).
+		Build())
+
+	assert.Len(t, logs.All(), 1)
+	assert.Equal(t, []zap.Field{
+		zap.String("body", "aaa"+observability.TruncationMarker),
+		zap.Int("dropped_values", 1),
+	}, logs.All()[0].Context)
+	assert.Equal(t, observability.LimitStats{DroppedValues: 1, TruncatedValues: 1}, limiter.Stats())
+}
@@ -2,11 +2,22 @@ package zap
 
 import (
 	"context"
+	"sync"
 
 	"github.com/sosalejandro/observability"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
+// fieldsPool reuses the buffers holding the fields of a log call,
+// zap cores encode or copy the fields before Write returns
+var fieldsPool = sync.Pool{
+	New: func() any {
+		fields := make([]zap.Field, 0, 16)
+		return &fields
+	},
+}
+
 // ZapLogger is a logger that uses Zap
 // It doesn't provide any additional functionality over the base ObservabilityLogger.
 //
@@ -21,32 +32,59 @@ func NewZapLogger(logger *zap.Logger) observability.ObservabilityLogger[zap.Fiel
 	return &ZapLogger[zap.Field]{logger: logger}
 }
 
+// Enabled checks if the zap core emits logs at the given level
+func (l *ZapLogger[T]) Enabled(level observability.Level) bool {
+	return l.logger.Core().Enabled(ZapLevel(level))
+}
+
 // LogInfo logs a message at the info level
 func (l *ZapLogger[T]) LogInfo(lv observability.LogValues[zap.Field]) {
-	l.logger.Info(lv.Msg(), lv.InfoValues()...)
+	l.log(zapcore.InfoLevel, observability.InfoLevel, lv)
 }
 
 // LogDebug logs a message at the debug level
 func (l *ZapLogger[T]) LogDebug(lv observability.LogValues[zap.Field]) {
-	l.logger.Debug(lv.Msg(), lv.DebugValues()...)
+	l.log(zapcore.DebugLevel, observability.DebugLevel, lv)
 }
 
 // LogError logs a message at the error level
 func (l *ZapLogger[T]) LogError(lv observability.LogValues[zap.Field]) {
-	l.logger.Error(lv.Msg(), lv.ErrorValues()...)
+	l.log(zapcore.ErrorLevel, observability.ErrorLevel, lv)
 }
 
 // LogInfoContext logs a message at the info level with a context (requires custom implementation)
 func (l *ZapLogger[T]) LogInfoContext(ctx context.Context, lv observability.LogValues[zap.Field]) {
-	l.logger.Info(lv.Msg(), lv.InfoValues()...)
+	l.log(zapcore.InfoLevel, observability.InfoLevel, lv)
 }
 
 // LogDebugContext logs a message at the debug level with a context (requires custom implementation)
 func (l *ZapLogger[T]) LogDebugContext(ctx context.Context, lv observability.LogValues[zap.Field]) {
-	l.logger.Debug(lv.Msg(), lv.DebugValues()...)
+	l.log(zapcore.DebugLevel, observability.DebugLevel, lv)
 }
 
 // LogErrorContext logs a message at the error level with a context (requires custom implementation)
 func (l *ZapLogger[T]) LogErrorContext(ctx context.Context, lv observability.LogValues[zap.Field]) {
-	l.logger.Error(lv.Msg(), lv.ErrorValues()...)
+	l.log(zapcore.ErrorLevel, observability.ErrorLevel, lv)
+}
+
+// Log logs a message at the closest zap level with the values of the given level, custom levels included
+func (l *ZapLogger[T]) Log(_ context.Context, level observability.Level, lv observability.LogValues[zap.Field]) {
+	l.log(ZapLevel(level), level, lv)
+}
+
+// log checks the level before building the values so lazy values of disabled levels aren't evaluated
+func (l *ZapLogger[T]) log(zapLevel zapcore.Level, level observability.Level, lv observability.LogValues[zap.Field]) {
+	ce := l.logger.Check(zapLevel, lv.Msg())
+	if ce == nil {
+		return
+	}
+
+	fields := fieldsPool.Get().(*[]zap.Field)
+	*fields = lv.AppendValues((*fields)[:0], level)
+	ce.Write(*fields...)
+
+	for i := range *fields {
+		(*fields)[i] = zap.Field{}
+	}
+	fieldsPool.Put(fields)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sosalejandro/observability"
 	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
@@ -123,3 +124,117 @@ func TestZapLogger_LogErrorContext(t *testing.T) {
 	assert.Equal(t, entry.Message, "test message")
 	assert.Equal(t, entry.Context, []zap.Field{field})
 }
+
+func TestZapLogger_AllLevelsValues(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	zapLogger := NewZapLogger(logger)
+
+	tracing := zap.String("tracing", "abc")
+	field := zap.String("foo", "bar")
+	lv := observability.NewLogValuesBuilder[zap.Field]().
+		WithAllLevelsValue(tracing).
+		WithInfoValue(field).
+		WithMsg("test message").
+		Build()
+
+	zapLogger.LogInfo(lv)
+	zapLogger.LogDebug(lv)
+
+	// Assert the all levels value is logged on every level before the level values
+	assert.Equal(t, logs.Len(), 2)
+	assert.Equal(t, logs.All()[0].Context, []zap.Field{tracing, field})
+	assert.Equal(t, logs.All()[1].Context, []zap.Field{tracing})
+}
+
+func TestZapLogger_Enabled(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	zapLogger := NewZapLogger(zap.New(core))
+
+	assert.False(t, zapLogger.Enabled(observability.DebugLevel))
+	assert.True(t, zapLogger.Enabled(observability.InfoLevel))
+	assert.True(t, zapLogger.Enabled(observability.ErrorLevel))
+}
+
+func TestZapLogger_LazyValues(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	zapLogger := NewZapLogger(zap.New(core))
+
+	calls := 0
+	lv := observability.NewLogValuesBuilder[zap.Field]().
+		WithLazyAllLevelsValue(func() zap.Field {
+			calls++
+			return zap.Int("expensive", calls)
+		}).
+		WithMsg("test message").
+		Build()
+
+	// Assert lazy values aren't evaluated for disabled levels
+	zapLogger.LogDebug(lv)
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, 0, logs.Len())
+
+	zapLogger.LogInfo(lv)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []zap.Field{zap.Int("expensive", 1)}, logs.All()[0].Context)
+}
+
+func TestZapHandler_Timer(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	h := NewZapHandler(context.Background(), "checkout", logger)
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	duration := h.StartTimer("charge card").Stop(observability.OutcomeSuccess)
+
+	// Assert the duration is logged as a zap duration
+	entry := logs.All()[0]
+	assert.Equal(t, "charge card", entry.Message)
+	assert.Equal(t, map[string]interface{}{
+		"duration": duration,
+		"outcome":  "success",
+	}, entry.ContextMap())
+}
+
+func TestZapHandler_Sugared(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	h := NewZapHandler(context.Background(), "checkout", logger,
+		observability.WithTracerProvider[zap.Field](sdktrace.NewTracerProvider()))
+	assert.NoError(t, h.SetTracingFormat(TracingFormat(observability.OTelTracingFormatter())))
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+	tv, _ := h.GetTraceValues()
+
+	h.Infow("charging card", "amount", 9.99, zap.String("currency", "EUR"))
+
+	entry := logs.All()[0]
+	assert.Equal(t, "charging card", entry.Message)
+	assert.Equal(t, map[string]interface{}{
+		"trace_id":    tv.TraceId,
+		"span_id":     tv.SpanId,
+		"trace_flags": "01",
+		"amount":      9.99,
+		"currency":    "EUR",
+	}, entry.ContextMap())
+}
+
+func TestZapHandler_CustomLevel(t *testing.T) {
+	const notice observability.Level = 2
+	logger, logs := setupLogsCapture()
+	h := NewZapHandler(context.Background(), "checkout", logger)
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	h.LogLevel(notice, observability.NewLogValuesBuilder[zap.Field]().
+		WithMsg("signed in").
+		WithValue(notice, zap.String("user", "alice")).
+		WithInfoValue(zap.String("hidden", "info")).
+		Build())
+	h.StartTimer("charge card").WithLevel(notice).Stop(observability.OutcomeSuccess)
+
+	assert.Equal(t, 2, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, zap.InfoLevel, entry.Level)
+	assert.Equal(t, []zap.Field{zap.String("user", "alice")}, entry.Context)
+	assert.Equal(t, "charge card", logs.All()[1].Message)
+	assert.Equal(t, "success", logs.All()[1].ContextMap()["outcome"])
+}
@@ -0,0 +1,26 @@
+package zap
+
+import (
+	"context"
+
+	"github.com/sosalejandro/observability"
+	"github.com/sosalejandro/observability/config"
+	"go.uber.org/zap"
+)
+
+// RedactionHook creates a hook masking the fields whose key is one of the configured keys
+func RedactionHook(cfg config.RedactionConfig) observability.LogHook[zap.Field] {
+	keys := cfg.RedactedKeys()
+	mask := cfg.Mask
+
+	redact := func(field zap.Field) zap.Field {
+		if _, ok := keys[field.Key]; ok {
+			return zap.String(field.Key, mask)
+		}
+		return field
+	}
+
+	return func(_ context.Context, _ observability.Level, lv observability.LogValues[zap.Field]) (observability.LogValues[zap.Field], bool) {
+		return lv.Builder().MapValues(redact).Build(), true
+	}
+}
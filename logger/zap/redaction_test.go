@@ -0,0 +1,24 @@
+package zap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sosalejandro/observability"
+	"github.com/sosalejandro/observability/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRedactionHook(t *testing.T) {
+	hook := RedactionHook(config.RedactionConfig{Keys: []string{"token"}, Mask: "***"})
+	lv := observability.NewLogValuesBuilder[zap.Field]().
+		WithAllLevelsValue(zap.String("token", "abc")).
+		WithInfoValue(zap.String("user", "alice")).
+		Build()
+
+	redacted, ok := hook(context.Background(), observability.InfoLevel, lv)
+	assert.True(t, ok)
+	assert.Equal(t, []zap.Field{zap.String("token", "***"), zap.String("user", "alice")}, redacted.Values(observability.InfoLevel))
+	assert.Equal(t, zap.String("token", "abc"), lv.Values(observability.InfoLevel)[0])
+}
@@ -0,0 +1,45 @@
+package zap
+
+import (
+	"github.com/sosalejandro/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TracingSetup is the function given to SetTracingFormat
+type TracingSetup func(name string, tv observability.TraceValues) zap.Field
+
+// TracingFormat creates a tracing setup rendering the keys of the given formatter
+// inline, so vendor keys such as dd.trace_id land at the top level of the log entry
+func TracingFormat(formatter observability.TracingFormatter) TracingSetup {
+	return func(_ string, tv observability.TraceValues) zap.Field {
+		return zap.Inline(attributes(formatter(tv)))
+	}
+}
+
+// TracingFormatByName creates the tracing setup of the given vendor convention
+// Refer to observability.TracingFormatterByName for the supported names
+func TracingFormatByName(name string, opts observability.TracingFormatterOptions) (TracingSetup, error) {
+	formatter, err := observability.TracingFormatterByName(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return TracingFormat(formatter), nil
+}
+
+// attributes marshals a list of attributes as the keys of a zap object
+type attributes []attribute.KeyValue
+
+// MarshalLogObject adds every attribute to the encoder
+func (attrs attributes) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, attr := range attrs {
+		addAttribute(enc, attr)
+	}
+	return nil
+}
+
+// addAttribute adds the attribute to the encoder with its typed value
+func addAttribute(enc zapcore.ObjectEncoder, attr attribute.KeyValue) {
+	AttributeField(attr).AddTo(enc)
+}
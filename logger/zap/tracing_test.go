@@ -0,0 +1,77 @@
+package zap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sosalejandro/observability"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+func TestTracingFormatByName(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	tv := observability.TraceValues{
+		TraceId: "5759e988bd862e3fe1be46a994272793",
+		SpanId:  "53995c3f42cd8ad8",
+	}
+
+	tests := map[string]map[string]interface{}{
+		"datadog": {
+			"dd.trace_id": "16266516598257821587",
+			"dd.span_id":  "6023947403358210776",
+		},
+		"gcp": {
+			"logging.googleapis.com/trace":         "projects/my-project/traces/5759e988bd862e3fe1be46a994272793",
+			"logging.googleapis.com/spanId":        "53995c3f42cd8ad8",
+			"logging.googleapis.com/trace_sampled": false,
+		},
+		"xray": {
+			"AWS-XRAY-TRACE-ID": "1-5759e988-bd862e3fe1be46a994272793@53995c3f42cd8ad8",
+		},
+	}
+
+	for name, want := range tests {
+		setup, err := TracingFormatByName(name, observability.TracingFormatterOptions{ProjectID: "my-project"})
+		assert.NoError(t, err)
+
+		logger.Info(name, setup("tracing", tv))
+		// Assert the vendor keys are logged at the top level
+		assert.Equal(t, want, logs.TakeAll()[0].ContextMap(), name)
+	}
+
+	_, err := TracingFormatByName("newrelic", observability.TracingFormatterOptions{})
+	assert.Error(t, err)
+}
+
+func TestTracingFormat_Empty(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	logger.Info("before span", TracingFormat(observability.DatadogTracingFormatter())("tracing", observability.TraceValues{}))
+	assert.Empty(t, logs.All()[0].ContextMap())
+}
+
+func TestTracingFormat_RefreshedOnStartSpan(t *testing.T) {
+	logger, logs := setupLogsCapture()
+	h := NewZapHandler(context.Background(), "checkout", logger,
+		observability.WithTracerProvider[zap.Field](sdktrace.NewTracerProvider()))
+	assert.NoError(t, h.SetTracingFormat(TracingFormat(observability.OTelTracingFormatter())))
+
+	for _, name := range []string{"first", "second"} {
+		_, end := h.StartSpan(name)
+		tv, _ := h.GetTraceValues()
+		h.LogInfo(h.CreateLogBuilder().CreateLogValuesBuilder().WithMsg(name).Build())
+		end()
+
+		// Assert every span logs its own ids
+		want := map[string]interface{}{
+			"trace_id":    tv.TraceId,
+			"span_id":     tv.SpanId,
+			"trace_flags": "01",
+		}
+		if tv.ParentSpanId != "" {
+			want["parent_span_id"] = tv.ParentSpanId
+		}
+		assert.Equal(t, want, logs.TakeAll()[0].ContextMap(), name)
+	}
+}
@@ -0,0 +1,153 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+)
+
+// LoggerDestination is a logger receiving the calls of a MultiLogger at or above its level
+type LoggerDestination[T any] struct {
+	Logger ObservabilityLogger[T]
+	// Level is the minimum level forwarded to the logger
+	Level Level
+}
+
+// NewLoggerDestination creates a new LoggerDestination for the given logger and minimum level
+func NewLoggerDestination[T any](logger ObservabilityLogger[T], level Level) LoggerDestination[T] {
+	return LoggerDestination[T]{Logger: logger, Level: level}
+}
+
+// MultiLogger is an ObservabilityLogger forwarding every call to several loggers
+//
+// A destination panicking doesn't prevent the others from receiving the call,
+// the panic is reported to the error handler. Slow destinations can be wrapped in an AsyncLogger.
+type MultiLogger[T any] struct {
+	destinations []LoggerDestination[T]
+	// onError is called when a destination fails
+	onError func(destination int, err error)
+}
+
+// NewMultiLogger creates a new MultiLogger forwarding to the given destinations
+func NewMultiLogger[T any](destinations ...LoggerDestination[T]) *MultiLogger[T] {
+	return &MultiLogger[T]{destinations: destinations}
+}
+
+// WithDestination adds a destination
+func (m *MultiLogger[T]) WithDestination(logger ObservabilityLogger[T], level Level) *MultiLogger[T] {
+	m.destinations = append(m.destinations, NewLoggerDestination(logger, level))
+	return m
+}
+
+// WithErrorHandler sets the function called with the index of the failing destination and its error
+func (m *MultiLogger[T]) WithErrorHandler(onError func(destination int, err error)) *MultiLogger[T] {
+	m.onError = onError
+	return m
+}
+
+// Enabled checks if any destination emits logs at the given level
+func (m *MultiLogger[T]) Enabled(level Level) bool {
+	for _, destination := range m.destinations {
+		if level >= destination.Level && destination.Logger.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// LogInfo logs an info message with the given values
+func (m *MultiLogger[T]) LogInfo(lv LogValues[T]) {
+	m.forward(logCall[T]{level: InfoLevel, lv: lv})
+}
+
+// LogError logs an error message with the given values
+func (m *MultiLogger[T]) LogError(lv LogValues[T]) {
+	m.forward(logCall[T]{level: ErrorLevel, lv: lv})
+}
+
+// LogDebug logs a debug message with the given values
+func (m *MultiLogger[T]) LogDebug(lv LogValues[T]) {
+	m.forward(logCall[T]{level: DebugLevel, lv: lv})
+}
+
+// LogInfoContext logs an info message with the given values and observability context
+func (m *MultiLogger[T]) LogInfoContext(ctx context.Context, lv LogValues[T]) {
+	m.forward(logCall[T]{level: InfoLevel, ctx: ctx, lv: lv})
+}
+
+// LogErrorContext logs an error message with the given values and observability context
+func (m *MultiLogger[T]) LogErrorContext(ctx context.Context, lv LogValues[T]) {
+	m.forward(logCall[T]{level: ErrorLevel, ctx: ctx, lv: lv})
+}
+
+// LogDebugContext logs a debug message with the given values and observability context
+func (m *MultiLogger[T]) LogDebugContext(ctx context.Context, lv LogValues[T]) {
+	m.forward(logCall[T]{level: DebugLevel, ctx: ctx, lv: lv})
+}
+
+// Log forwards a message at the given level to every destination accepting it, refer to LevelLogger
+func (m *MultiLogger[T]) Log(ctx context.Context, level Level, lv LogValues[T]) {
+	m.forward(logCall[T]{level: level, ctx: ctx, lv: lv})
+}
+
+// RequiresSpanContext reports whether a destination requires the context of every call, refer to SpanContextLogger
+func (m *MultiLogger[T]) RequiresSpanContext() bool {
+	for _, destination := range m.destinations {
+		if requiresSpanContext(destination.Logger) {
+			return true
+		}
+	}
+	return false
+}
+
+// SpanStarted forwards the start of the span to every destination implementing SpanStartObserver
+func (m *MultiLogger[T]) SpanStarted(tv TraceValues) {
+	m.notifyAll(notifySpanStarted, tv)
+}
+
+// SpanEnded forwards the end of the span to every destination implementing SpanEndObserver
+func (m *MultiLogger[T]) SpanEnded(tv TraceValues) {
+	m.notifyAll(notifySpanEnded, tv)
+}
+
+// notifyAll notifies every destination with the given function
+func (m *MultiLogger[T]) notifyAll(notify func(logger any, tv TraceValues), tv TraceValues) {
+	for i, destination := range m.destinations {
+		if err := m.notify(notify, destination.Logger, tv); err != nil && m.onError != nil {
+			m.onError(i, err)
+		}
+	}
+}
+
+// notify notifies the logger with the given function recovering from its panics
+func (m *MultiLogger[T]) notify(notify func(logger any, tv TraceValues), logger ObservabilityLogger[T], tv TraceValues) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("logger panicked: %v", r)
+		}
+	}()
+	notify(logger, tv)
+	return nil
+}
+
+// forward forwards the call to every destination accepting its level
+func (m *MultiLogger[T]) forward(call logCall[T]) {
+	for i, destination := range m.destinations {
+		if call.level < destination.Level {
+			continue
+		}
+		if err := m.dispatch(call, destination.Logger); err != nil && m.onError != nil {
+			m.onError(i, err)
+		}
+	}
+}
+
+// dispatch forwards the call to the logger recovering from its panics
+func (m *MultiLogger[T]) dispatch(call logCall[T], logger ObservabilityLogger[T]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("logger panicked: %v", r)
+		}
+	}()
+	call.dispatch(logger)
+	return nil
+}
@@ -0,0 +1,62 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// panickingLogger is an ObservabilityLogger failing on every call
+type panickingLogger struct {
+	recordingLogger
+}
+
+func (l *panickingLogger) LogInfo(LogValues[string]) { panic("sink unavailable") }
+
+func (l *panickingLogger) LogError(LogValues[string]) { panic("sink unavailable") }
+
+// Test that the MultiLogger forwards each call to the destinations accepting its level
+func TestMultiLogger_Levels(t *testing.T) {
+	console := &recordingLogger{level: DebugLevel}
+	remote := &recordingLogger{level: DebugLevel}
+	m := NewMultiLogger[string](NewLoggerDestination[string](console, DebugLevel)).
+		WithDestination(remote, ErrorLevel)
+
+	m.LogDebug(msg("debug"))
+	m.LogInfoContext(context.Background(), msg("info"))
+	m.LogError(msg("error"))
+
+	assert.Equal(t, []string{"debug", "info", "error"}, console.messages())
+	assert.Equal(t, []string{"error"}, remote.messages())
+}
+
+// Test that a failing destination doesn't prevent the others from logging
+func TestMultiLogger_ErrorIsolation(t *testing.T) {
+	console := &recordingLogger{level: DebugLevel}
+	var failed []int
+	m := NewMultiLogger[string]().
+		WithDestination(&panickingLogger{}, DebugLevel).
+		WithDestination(console, DebugLevel).
+		WithErrorHandler(func(destination int, err error) {
+			failed = append(failed, destination)
+			assert.EqualError(t, err, "logger panicked: sink unavailable")
+		})
+
+	m.LogInfo(msg("info"))
+	m.LogError(msg("error"))
+
+	assert.Equal(t, []string{"info", "error"}, console.messages())
+	assert.Equal(t, []int{0, 0}, failed)
+}
+
+// Test that Enabled checks the destinations levels and loggers
+func TestMultiLogger_Enabled(t *testing.T) {
+	m := NewMultiLogger[string]().
+		WithDestination(&recordingLogger{level: InfoLevel}, DebugLevel).
+		WithDestination(&recordingLogger{level: DebugLevel}, ErrorLevel)
+
+	assert.False(t, m.Enabled(DebugLevel))
+	assert.True(t, m.Enabled(InfoLevel))
+	assert.False(t, NewMultiLogger[string]().Enabled(ErrorLevel))
+}
@@ -3,13 +3,13 @@ package observability
 import (
 	"context"
 	"errors"
-	"reflect"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
-// TracesValues contains the traceId and spanId.
+// TracesValues contains the traceId and spanId along with the parent, flags and state of the span.
 //
 // Requires to setup zapcore.ObjectMarshaler  on zap.Object & slog.GroupValue on slog.Group
 // transformations to zap.Field & slog.Attr respectively.
@@ -17,12 +17,41 @@ import (
 type TraceValues struct {
 	TraceId string
 	SpanId  string
+	// ParentSpanId is the id of the parent span, empty for root spans
+	ParentSpanId string
+	// Sampled is set when the trace is sampled
+	Sampled bool
+	// TraceFlags are the W3C trace flags in hex, e.g. 01
+	TraceFlags string
+	// TraceState is the W3C tracestate of the span, e.g. vendor=value
+	TraceState string
+	// Remote is set when the parent span was propagated from another process
+	Remote bool
+}
+
+// TraceValuesFromSpanContext creates the trace values of the span with the given span context
+// The parent is ignored when it's invalid or belongs to another trace
+func TraceValuesFromSpanContext(sc, parent trace.SpanContext) TraceValues {
+	tv := TraceValues{
+		TraceId:    sc.TraceID().String(),
+		SpanId:     sc.SpanID().String(),
+		Sampled:    sc.IsSampled(),
+		TraceFlags: sc.TraceFlags().String(),
+		TraceState: sc.TraceState().String(),
+	}
+	if parent.IsValid() && parent.TraceID() == sc.TraceID() {
+		tv.ParentSpanId = parent.SpanID().String()
+		tv.Remote = parent.IsRemote()
+	}
+	return tv
 }
 
 type ObservabilityLogging[T any] interface {
 	// CreateLogBuilder creates a new LogBuilder for compatible log values with the given type
 	// Receives a function that sets up tracing for the log values
 	CreateLogBuilder() *LogBuilder[T]
+	// Enabled checks if logs at the given level would be emitted
+	Enabled(level Level) bool
 	// LogInfo logs an info message with the given values
 	LogInfo(lv LogValues[T], opts ...trace.EventOption)
 	// LogError logs an error message with the given values
@@ -35,9 +64,15 @@ type ObservabilityLogging[T any] interface {
 	LogErrorContext(lv LogValues[T], opts ...trace.EventOption)
 	// LogDebugContext logs a debug message with the given values and observability context
 	LogDebugContext(lv LogValues[T], opts ...trace.EventOption)
+	// LogLevel logs a message at the given level, custom levels included, with the given values
+	LogLevel(level Level, lv LogValues[T], opts ...trace.EventOption)
+	// LogLevelContext logs a message at the given level, custom levels included, with the given values and observability context
+	LogLevelContext(level Level, lv LogValues[T], opts ...trace.EventOption)
 }
 
 type ObservabilityLogger[T any] interface {
+	// Enabled checks if the logger emits logs at the given level
+	Enabled(level Level) bool
 	// LogInfo logs an info message with the given values
 	LogInfo(lv LogValues[T])
 	// LogError logs an error message with the given values
@@ -56,10 +91,33 @@ type ObservabilityHandler[T any] interface {
 	// StartSpan starts a span with the given name and options
 	// and returns the context and a function to shutdown the span
 	StartSpan(name string, opts ...trace.SpanStartOption) (ctx context.Context, shutdown func(...trace.SpanEndOption))
+	// StartSpanWithLinks starts a span linked to the given spans
+	// and returns the context and a function to shutdown the span
+	StartSpanWithLinks(name string, links []trace.Link, opts ...trace.SpanStartOption) (ctx context.Context, shutdown func(...trace.SpanEndOption))
+	// AddLink adds a link to the next span started by the handler
+	AddLink(link trace.Link)
+	// SetAttributes sets the given attributes on the current span
+	SetAttributes(kv ...attribute.KeyValue)
+	// StartTimer starts a timer measuring the named operation, refer to Timer
+	StartTimer(name string, attrs ...attribute.KeyValue) *Timer[T]
+	// Context returns the context of the handler, carrying its current span
+	Context() context.Context
+	// WithContext returns a handler sharing the settings of the handler
+	// whose spans are children of the span in the given context
+	WithContext(ctx context.Context) ObservabilityHandler[T]
 	GetTraceValues() (TraceValues, error)
-	// SetTracingFormat sets the tracing format for the given tracingSetup function
-	// Requires the SetTracingFormat to not have been called before
+	// SetTracingFormat sets the tracingSetup function used to format the trace values
+	// The tracing format is regenerated on every StartSpan and calling it again replaces the function
 	SetTracingFormat(tracingSetup func(string, TraceValues) T) error
+	// Debugw logs a debug message with loose key-value pairs converted by the ValueConverter
+	Debugw(msg string, keysAndValues ...any)
+	// Infow logs an info message with loose key-value pairs converted by the ValueConverter
+	Infow(msg string, keysAndValues ...any)
+	// Errorw logs an error message with loose key-value pairs converted by the ValueConverter
+	Errorw(msg string, keysAndValues ...any)
+	// ConvertValue converts the key and the value into a log value with the ValueConverter
+	// Returns false when the handler has no converter
+	ConvertValue(key string, value any) (T, bool)
 	ObservabilityLogging[T]
 }
 
@@ -76,111 +134,383 @@ type ObservabilityContext[T any] struct {
 	span trace.Span
 	// Logger used for observability
 	logger ObservabilityLogger[T]
-	// traceId is the id of the trace
-	traceId string
-	// spanId is the id of the span
-	spanId string
-	// tracingFormat is a zapcore.Field or slog.Attr that contains the traceId and spanId
+	// traceValues are the trace values of the span, computed once per span
+	traceValues TraceValues
+	// spanName is the name of the span
+	spanName string
+	// tracingSetup formats the trace values into a zapcore.Field or slog.Attr, nil when no tracing format is set
 	// requires setup of zapcore.ObjectEncoder & slog.Group transformations to zapcore.Field & slog.Attr respectively
 	// refer to https://pkg.go.dev/golang.org/x/exp/slog#Group and https://github.com/uber-go/zap/blob/v1.24.0/field.go#L399
-	tracingFormat T
-	// traceOptions are the options used for tracing
+	tracingSetup func(string, TraceValues) T
+	// traceOptions are the options used for tracing, computed once per span
 	traceOptions []trace.EventOption
+	// logBuilder is shared by every CreateLogBuilder call and pools the log values builders
+	logBuilder *LogBuilder[T]
+	// links are the links added to the next span
+	links []trace.Link
+	// tracerProvider starts the spans, the provider of the span in the context is used when nil
+	tracerProvider trace.TracerProvider
+	// hooks run before every log and can modify or veto it
+	hooks LogHookChain[T]
+	// levels decides which levels are enabled at runtime, every level is enabled when nil
+	levels *LevelController
+	// routing decides whether the logs are sent to the logger, the span or both, both when nil
+	routing *RoutingPolicy
+	// limiter bounds the size of the logs and span events, unlimited when nil
+	limiter *Limiter[T]
+	// convert converts keys and values into log values, e.g. for the durations of the timers
+	convert ValueConverter[T]
+	// timerHistogram records the durations of the timers in seconds, nothing is recorded when nil
+	timerHistogram metric.Float64Histogram
+	// recorder keeps the last logs of every level, nothing is recorded when nil
+	recorder *FlightRecorder[T]
+	// spanContextLogger is set when the logger requires the context of every call, refer to SpanContextLogger
+	spanContextLogger bool
+	// spanEvents counts the events of the current span for the limiter, shared by the handlers logging onto the span
+	// It's nil without limiter
+	spanEvents *spanEventCounter
+}
+
+// ObservabilityOption configures an ObservabilityContext
+type ObservabilityOption[T any] func(*ObservabilityContext[T])
+
+// WithLevelController sets the LevelController consulted before logging and adding span events
+func WithLevelController[T any](levels *LevelController) ObservabilityOption[T] {
+	return func(oc *ObservabilityContext[T]) {
+		oc.levels = levels
+	}
+}
+
+// WithRoutingPolicy sets the policy deciding whether the logs of each level are sent to the logger, the span or both
+// Every log is sent to both by default
+func WithRoutingPolicy[T any](policy *RoutingPolicy) ObservabilityOption[T] {
+	return func(oc *ObservabilityContext[T]) {
+		oc.routing = policy
+	}
+}
+
+// WithLimiter sets the Limiter truncating the logs and bounding the events added to each span
+func WithLimiter[T any](limiter *Limiter[T]) ObservabilityOption[T] {
+	return func(oc *ObservabilityContext[T]) {
+		oc.limiter = limiter
+	}
+}
+
+// WithValueConverter sets the converter of keys and values into log values, e.g. zap.Any
+// Timers only log their name without converter
+func WithValueConverter[T any](convert ValueConverter[T]) ObservabilityOption[T] {
+	return func(oc *ObservabilityContext[T]) {
+		oc.convert = convert
+	}
+}
+
+// WithTimerHistogram sets the histogram recording the durations of the timers in seconds
+// with the timer.name and timer.outcome attributes
+func WithTimerHistogram[T any](histogram metric.Float64Histogram) ObservabilityOption[T] {
+	return func(oc *ObservabilityContext[T]) {
+		oc.timerHistogram = histogram
+	}
+}
+
+// WithFlightRecorder sets the FlightRecorder keeping the last logs, including the ones of the disabled levels
+func WithFlightRecorder[T any](recorder *FlightRecorder[T]) ObservabilityOption[T] {
+	return func(oc *ObservabilityContext[T]) {
+		oc.recorder = recorder
+	}
 }
 
-func NewObservabilityHandler[T any](ctx context.Context, serviceName string, logger ObservabilityLogger[T]) ObservabilityHandler[T] {
-	return &ObservabilityContext[T]{
-		ctx:         ctx,
-		serviceName: serviceName,
-		logger:      logger,
+// WithTracerProvider sets the TracerProvider used to start the spans
+// The provider of the span in the context is used by default
+func WithTracerProvider[T any](tp trace.TracerProvider) ObservabilityOption[T] {
+	return func(oc *ObservabilityContext[T]) {
+		oc.tracerProvider = tp
 	}
 }
 
+// WithLogHooks adds hooks run before every log, refer to LogHook
+func WithLogHooks[T any](hooks ...LogHook[T]) ObservabilityOption[T] {
+	return func(oc *ObservabilityContext[T]) {
+		oc.hooks = oc.hooks.Append(hooks...)
+	}
+}
+
+func NewObservabilityHandler[T any](ctx context.Context, serviceName string, logger ObservabilityLogger[T], opts ...ObservabilityOption[T]) ObservabilityHandler[T] {
+	oc := &ObservabilityContext[T]{
+		ctx:               ctx,
+		serviceName:       serviceName,
+		logger:            logger,
+		logBuilder:        NewLogBuilderWithPool[T](NewLogValuesBuilderPool[T]()),
+		spanContextLogger: requiresSpanContext(logger),
+	}
+	for _, opt := range opts {
+		opt(oc)
+	}
+	return oc
+}
+
 // StartSpan starts a span with the given name and options
 // and returns the context and a function to shutdown the span
+// Loggers implementing SpanStartObserver are notified once the span started,
+// and the ones implementing SpanEndObserver once it ends
 func (oc *ObservabilityContext[T]) StartSpan(name string, opts ...trace.SpanStartOption) (context.Context, func(...trace.SpanEndOption)) {
-	oc.ctx, oc.span = trace.SpanFromContext(oc.ctx).TracerProvider().Tracer(oc.serviceName).
-		Start(oc.ctx, name, opts...)
-
-	oc.traceId = oc.span.SpanContext().TraceID().String()
-	oc.spanId = oc.span.SpanContext().SpanID().String()
+	if len(oc.links) > 0 {
+		opts = append([]trace.SpanStartOption{trace.WithLinks(oc.links...)}, opts...)
+		oc.links = nil
+	}
 
-	oc.traceOptions = make([]trace.EventOption, 0)
-	oc.traceOptions = append(oc.traceOptions, trace.WithAttributes(
-		attribute.String("traceId", oc.traceId),
-		attribute.String("spanId", oc.spanId),
-	))
+	tp := oc.tracerProvider
+	if tp == nil {
+		tp = trace.SpanFromContext(oc.ctx).TracerProvider()
+	}
+	parent := trace.SpanContextFromContext(oc.ctx)
+	ctx, span := tp.Tracer(oc.serviceName).Start(oc.ctx, name, opts...)
+	oc.ctx = ContextWithHandler[T](ctx, oc)
+	oc.setSpan(name, span, parent)
+	oc.spanEvents = oc.newSpanEventCounter()
+	notifySpanStarted(oc.logger, oc.traceValues)
 
+	if observer, ok := oc.logger.(SpanEndObserver); ok {
+		tv := oc.traceValues
+		return oc.ctx, func(opts ...trace.SpanEndOption) {
+			span.End(opts...)
+			observer.SpanEnded(tv)
+		}
+	}
 	return oc.ctx, oc.span.End
 }
 
-// CreateLogBuilder creates a new LogBuilder for compatible log values with the given type
+// Context returns the context of the handler, carrying its current span once started
+func (oc *ObservabilityContext[T]) Context() context.Context {
+	return oc.ctx
+}
+
+// WithContext returns a handler sharing the logger, levels, hooks and tracing format of the handler
+// whose spans are children of the span in the given context
+// The span in the context, if any, is used as the current span of the returned handler
+// The returned handler starts its spans with the provider of the handler's span when no provider is set
+func (oc *ObservabilityContext[T]) WithContext(ctx context.Context) ObservabilityHandler[T] {
+	tp := oc.tracerProvider
+	if tp == nil && oc.span != nil {
+		tp = oc.span.TracerProvider()
+	}
+	derived := &ObservabilityContext[T]{
+		ctx:               ctx,
+		serviceName:       oc.serviceName,
+		logger:            oc.logger,
+		tracingSetup:      oc.tracingSetup,
+		logBuilder:        NewLogBuilderWithPool[T](oc.logBuilder.pool),
+		tracerProvider:    tp,
+		hooks:             oc.hooks,
+		levels:            oc.levels,
+		routing:           oc.routing,
+		limiter:           oc.limiter,
+		convert:           oc.convert,
+		timerHistogram:    oc.timerHistogram,
+		recorder:          oc.recorder,
+		spanContextLogger: oc.spanContextLogger,
+	}
+	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
+		derived.setSpan("", span, trace.SpanContext{})
+		derived.spanEvents = derived.sharedSpanEventCounter(ctx, oc)
+	}
+	return derived
+}
+
+// newSpanEventCounter returns a counter for a new span, nil without limiter as nothing is counted
+func (oc *ObservabilityContext[T]) newSpanEventCounter() *spanEventCounter {
+	if oc.limiter == nil {
+		return nil
+	}
+	return &spanEventCounter{}
+}
+
+// sharedSpanEventCounter returns the counter of the handler logging onto the current span,
+// either the handler in ctx or the given one, so deriving handlers doesn't reset the events of the span
+func (oc *ObservabilityContext[T]) sharedSpanEventCounter(ctx context.Context, from *ObservabilityContext[T]) *spanEventCounter {
+	if oc.limiter == nil {
+		return nil
+	}
+	spanID := oc.span.SpanContext().SpanID()
+	candidates := []*ObservabilityContext[T]{from}
+	if h, ok := HandlerFromContext[T](ctx); ok {
+		if handler, ok := h.(*ObservabilityContext[T]); ok {
+			candidates = append([]*ObservabilityContext[T]{handler}, candidates...)
+		}
+	}
+	for _, candidate := range candidates {
+		if candidate.spanEvents != nil && candidate.span != nil && candidate.span.SpanContext().SpanID() == spanID {
+			return candidate.spanEvents
+		}
+	}
+	return &spanEventCounter{}
+}
+
+// setSpan sets the current span and computes its trace values, trace options and tracing format
+func (oc *ObservabilityContext[T]) setSpan(name string, span trace.Span, parent trace.SpanContext) {
+	oc.span = span
+	oc.spanName = name
+	oc.traceValues = TraceValuesFromSpanContext(span.SpanContext(), parent)
+
+	oc.traceOptions = []trace.EventOption{trace.WithAttributes(
+		attribute.String("traceId", oc.traceValues.TraceId),
+		attribute.String("spanId", oc.traceValues.SpanId),
+	)}
+	oc.refreshTracingFormat()
+}
+
+// StartSpanWithLinks starts a span linked to the given spans
+// and returns the context and a function to shutdown the span
+func (oc *ObservabilityContext[T]) StartSpanWithLinks(name string, links []trace.Link, opts ...trace.SpanStartOption) (context.Context, func(...trace.SpanEndOption)) {
+	startOpts := make([]trace.SpanStartOption, 0, len(opts)+1)
+	startOpts = append(startOpts, opts...)
+	return oc.StartSpan(name, append(startOpts, trace.WithLinks(links...))...)
+}
+
+// AddLink adds a link to the next span started by the handler
+// Links can only be set when a span starts, the current span isn't modified
+func (oc *ObservabilityContext[T]) AddLink(link trace.Link) {
+	oc.links = append(oc.links, link)
+}
+
+// SetAttributes sets the given attributes on the current span, e.g. the semantic convention helpers
+// Nothing is set when the span hasn't been started yet
+func (oc *ObservabilityContext[T]) SetAttributes(kv ...attribute.KeyValue) {
+	if oc.span == nil {
+		return
+	}
+	oc.span.SetAttributes(kv...)
+}
+
+// CreateLogBuilder returns the LogBuilder for compatible log values with the given type
+// The LogBuilder pools its log values builders, refer to AcquireLogValuesBuilder
+// Once a span has started, the builders created include the tracing format of the current span
 func (oc *ObservabilityContext[T]) CreateLogBuilder() *LogBuilder[T] {
+	return oc.logBuilder
+}
 
-	lb := NewLogBuilder[T]()
-	if oc.span != nil && !isNil[T](oc.tracingFormat) {
-		lb.CreateLogValuesBuilder().
-			WithDebugValue(oc.tracingFormat).
-			WithErrorValue(oc.tracingFormat).
-			WithInfoValue(oc.tracingFormat)
+// ConvertValue converts the key and the value into a log value with the ValueConverter
+// Returns false when the handler has no converter
+func (oc *ObservabilityContext[T]) ConvertValue(key string, value any) (T, bool) {
+	if oc.convert == nil {
+		var zero T
+		return zero, false
 	}
+	return oc.convert(key, value), true
+}
 
-	return lb
+// Enabled checks if logs at the given level would be emitted
+// by both the LevelController and the logger
+func (oc *ObservabilityContext[T]) Enabled(level Level) bool {
+	return oc.enabled(level) && oc.logger.Enabled(level)
 }
 
 // LogInfo logs an info message with the given values
 func (oc *ObservabilityContext[T]) LogInfo(lv LogValues[T], opts ...trace.EventOption) {
-	oc.span.AddEvent(
-		lv.Msg(),
-		withTraceOptions(oc.traceOptions, opts...)...,
-	)
-
-	oc.logger.LogInfo(lv)
+	oc.log(InfoLevel, false, lv, opts)
 }
 
 // LogError logs an error message with the given values
 func (oc *ObservabilityContext[T]) LogError(lv LogValues[T], opts ...trace.EventOption) {
-	oc.span.RecordError(
-		lv.Err(),
-		withTraceOptions(oc.traceOptions, opts...)...,
-	)
-	oc.logger.LogError(lv)
+	oc.log(ErrorLevel, false, lv, opts)
 }
 
 // LogDebug logs a debug message with the given values
 func (oc *ObservabilityContext[T]) LogDebug(lv LogValues[T], opts ...trace.EventOption) {
-	oc.span.AddEvent(
-		lv.Msg(),
-		withTraceOptions(oc.traceOptions, opts...)...,
-	)
-	oc.logger.LogDebug(lv)
+	oc.log(DebugLevel, false, lv, opts)
 }
 
 // LogInfoContext logs an info message with the given values and observability context
 func (oc *ObservabilityContext[T]) LogInfoContext(lv LogValues[T], opts ...trace.EventOption) {
-	oc.span.AddEvent(
-		lv.Msg(),
-		withTraceOptions(oc.traceOptions, opts...)...,
-	)
-	oc.logger.LogInfoContext(oc.ctx, lv)
+	oc.log(InfoLevel, true, lv, opts)
 }
 
 // LogErrorContext logs an error message with the given values and observability context
 func (oc *ObservabilityContext[T]) LogErrorContext(lv LogValues[T], opts ...trace.EventOption) {
-	oc.span.RecordError(
-		lv.Err(),
-		withTraceOptions(oc.traceOptions, opts...)...,
-	)
-	oc.logger.LogErrorContext(oc.ctx, lv)
+	oc.log(ErrorLevel, true, lv, opts)
 }
 
 // LogDebugContext logs a debug message with the given values and observability context
 func (oc *ObservabilityContext[T]) LogDebugContext(lv LogValues[T], opts ...trace.EventOption) {
-	oc.span.AddEvent(
-		lv.Msg(),
-		withTraceOptions(oc.traceOptions, opts...)...,
-	)
-	oc.logger.LogDebugContext(oc.ctx, lv)
+	oc.log(DebugLevel, true, lv, opts)
+}
+
+// LogLevel logs a message at the given level with the given values
+// Custom levels reach LevelLogger loggers as is, the other loggers receive them at the closest built-in level below them
+func (oc *ObservabilityContext[T]) LogLevel(level Level, lv LogValues[T], opts ...trace.EventOption) {
+	oc.log(level, false, lv, opts)
+}
+
+// LogLevelContext logs a message at the given level with the given values and observability context
+// Custom levels reach LevelLogger loggers as is, the other loggers receive them at the closest built-in level below them
+func (oc *ObservabilityContext[T]) LogLevelContext(level Level, lv LogValues[T], opts ...trace.EventOption) {
+	oc.log(level, true, lv, opts)
+}
+
+// log checks the level and runs the hooks before adding the span event and calling the logger
+// as decided by the route of the log values or the RoutingPolicy
+// Errors are recorded on the span, other levels are added as span events
+// The FlightRecorder receives the logs of every level once the hooks ran
+func (oc *ObservabilityContext[T]) log(level Level, withContext bool, lv LogValues[T], opts []trace.EventOption) {
+	enabled := oc.enabled(level)
+	if !enabled && oc.recorder == nil {
+		return
+	}
+
+	lv, ok := oc.hooks.Run(oc.ctx, level, lv)
+	if !ok {
+		return
+	}
+	if oc.recorder != nil {
+		oc.recorder.record(level, oc.traceValues, lv)
+		if level >= ErrorLevel {
+			// the dump runs once the error itself was logged
+			defer oc.recorder.errorRecorded()
+		}
+	}
+	if !enabled {
+		return
+	}
+	if oc.limiter != nil {
+		lv = oc.limiter.Apply(level, lv)
+	}
+
+	route := lv.Route()
+	if route == 0 {
+		route = oc.routing.Route(level)
+	}
+	if route&RouteSpan != 0 && oc.span != nil {
+		oc.addSpanEvent(level, lv, opts)
+	}
+	if route&RouteLogger == 0 {
+		return
+	}
+
+	call := logCall[T]{level: level, lv: lv}
+	if withContext || oc.spanContextLogger {
+		call.ctx = oc.ctx
+	}
+	call.dispatch(oc.logger)
+}
+
+// addSpanEvent records the errors on the span and adds the other levels as span events
+// Events beyond the limit of the Limiter are dropped and counted in the observability.dropped_span_events attribute
+func (oc *ObservabilityContext[T]) addSpanEvent(level Level, lv LogValues[T], opts []trace.EventOption) {
+	if oc.spanEvents != nil && !oc.limiter.allowSpanEvent(oc.spanEvents) {
+		oc.span.SetAttributes(attribute.Int64("observability.dropped_span_events", oc.spanEvents.dropped.Load()))
+		return
+	}
+
+	if level >= ErrorLevel {
+		oc.span.RecordError(
+			lv.Err(),
+			oc.eventOptions(opts)...,
+		)
+	} else {
+		oc.span.AddEvent(
+			lv.Msg(),
+			oc.eventOptions(opts)...,
+		)
+	}
 }
 
 // GetTraceValues returns the trace values
@@ -191,39 +521,48 @@ func (oc *ObservabilityContext[T]) GetTraceValues() (TraceValues, error) {
 		return TraceValues{}, errors.New("span haven't been started yet")
 	}
 
-	return TraceValues{
-		TraceId: oc.traceId,
-		SpanId:  oc.spanId,
-	}, nil
+	return oc.traceValues, nil
 }
 
-// SetTracingFormat sets the tracing format for the given tracingSetup function
-// Requires the SetTracingFormat to not have been called before
+// SetTracingFormat sets the tracingSetup function used to format the trace values
 // tracingSetup is a function that receives the name of the field and the trace values
 // and returns the tracing format
+// The tracing format is regenerated on every StartSpan, calling it again replaces the function
+// Returns an error if tracingSetup is nil
 func (oc *ObservabilityContext[T]) SetTracingFormat(tracingSetup func(string, TraceValues) T) error {
-	if !isNil[T](oc.tracingFormat) {
-		return errors.New("tracing format already set")
+	if tracingSetup == nil {
+		return errors.New("tracing setup is required")
 	}
 
-	traceValues, _ := oc.GetTraceValues()
-
-	oc.tracingFormat = tracingSetup("tracing", traceValues)
+	oc.tracingSetup = tracingSetup
+	oc.refreshTracingFormat()
 
 	return nil
 }
 
-// isNil checks if the given value is nil
-func isNil[T any](value T) bool {
-	// Get the value's underlying type
-	valueType := reflect.TypeOf(value)
+// refreshTracingFormat regenerates the tracing format with the current trace values
+// The tracing format is only added to the log builder once a span has started
+func (oc *ObservabilityContext[T]) refreshTracingFormat() {
+	traceValues, err := oc.GetTraceValues()
+	if err != nil || oc.tracingSetup == nil {
+		return
+	}
+	oc.logBuilder.WithAllLevelsValues(oc.tracingSetup("tracing", traceValues))
+}
 
-	// Check if the value is the zero value for its type
-	return reflect.ValueOf(value).IsZero() && valueType.Kind() != reflect.Ptr
+// enabled checks if the given level is enabled by the LevelController
+func (oc *ObservabilityContext[T]) enabled(level Level) bool {
+	return oc.levels == nil || oc.levels.Enabled(level, oc.serviceName, oc.spanName)
 }
 
-// WithTraceOptions adds the given options to the base options
-func withTraceOptions(baseOpts []trace.EventOption, opts ...trace.EventOption) []trace.EventOption {
-	baseOpts = append(baseOpts, opts...)
-	return baseOpts
+// eventOptions adds the given options to the trace options
+// The trace options are returned as is when there are no options to add
+func (oc *ObservabilityContext[T]) eventOptions(opts []trace.EventOption) []trace.EventOption {
+	if len(opts) == 0 {
+		return oc.traceOptions
+	}
+
+	eventOpts := make([]trace.EventOption, 0, len(oc.traceOptions)+len(opts))
+	eventOpts = append(eventOpts, oc.traceOptions...)
+	return append(eventOpts, opts...)
 }
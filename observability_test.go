@@ -0,0 +1,226 @@
+package observability
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// logEntry is a log call captured by recordingLogger
+type logEntry struct {
+	level Level
+	msg   string
+	ctx   context.Context
+	lv    LogValues[string]
+}
+
+// recordingLogger is an ObservabilityLogger capturing every call
+type recordingLogger struct {
+	mu      sync.Mutex
+	level   Level
+	entries []logEntry
+	// entered receives a value when a call starts, if set
+	entered chan struct{}
+	// gate blocks every call until it's closed, if set
+	gate chan struct{}
+}
+
+func (l *recordingLogger) record(ctx context.Context, level Level, lv LogValues[string]) {
+	if l.entered != nil {
+		l.entered <- struct{}{}
+	}
+	if l.gate != nil {
+		<-l.gate
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, msg: lv.Msg(), ctx: ctx, lv: lv})
+}
+
+func (l *recordingLogger) all() []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]logEntry(nil), l.entries...)
+}
+
+func (l *recordingLogger) Enabled(level Level) bool { return level >= l.level }
+
+func (l *recordingLogger) LogInfo(lv LogValues[string]) { l.record(nil, InfoLevel, lv) }
+
+func (l *recordingLogger) LogError(lv LogValues[string]) { l.record(nil, ErrorLevel, lv) }
+
+func (l *recordingLogger) LogDebug(lv LogValues[string]) { l.record(nil, DebugLevel, lv) }
+
+func (l *recordingLogger) LogInfoContext(ctx context.Context, lv LogValues[string]) {
+	l.record(ctx, InfoLevel, lv)
+}
+
+func (l *recordingLogger) LogErrorContext(ctx context.Context, lv LogValues[string]) {
+	l.record(ctx, ErrorLevel, lv)
+}
+
+func (l *recordingLogger) LogDebugContext(ctx context.Context, lv LogValues[string]) {
+	l.record(ctx, DebugLevel, lv)
+}
+
+func (l *recordingLogger) messages() []string {
+	var msgs []string
+	for _, entry := range l.all() {
+		msgs = append(msgs, entry.msg)
+	}
+	return msgs
+}
+
+func msg(text string) LogValues[string] {
+	return NewLogValuesBuilder[string]().WithMsg(text).Build()
+}
+
+// Test that the LevelController decides which levels reach the logger
+func TestObservabilityContext_LevelController(t *testing.T) {
+	logger := &recordingLogger{level: DebugLevel}
+	levels := NewLevelController(InfoLevel)
+	h := NewObservabilityHandler[string](context.Background(), "checkout", logger, WithLevelController[string](levels))
+	_, end := h.StartSpan("GET /orders")
+	defer end()
+
+	h.LogDebug(msg("hidden"))
+	h.LogInfo(msg("info"))
+
+	levels.SetSpanLevel("GET /orders", DebugLevel)
+	h.LogDebugContext(msg("debug"))
+
+	levels.SetServiceLevel("checkout", ErrorLevel)
+	levels.ClearSpanLevel("GET /orders")
+	h.LogInfoContext(msg("hidden"))
+	h.LogError(msg("error"))
+
+	assert.Equal(t, []string{"info", "debug", "error"}, logger.messages())
+}
+
+// Test that Enabled accounts for both the LevelController and the logger
+func TestObservabilityContext_Enabled(t *testing.T) {
+	levels := NewLevelController(DebugLevel)
+	h := NewObservabilityHandler[string](context.Background(), "checkout", &recordingLogger{level: InfoLevel}, WithLevelController[string](levels))
+
+	assert.False(t, h.Enabled(DebugLevel))
+	assert.True(t, h.Enabled(InfoLevel))
+
+	levels.SetLevel(ErrorLevel)
+	assert.False(t, h.Enabled(InfoLevel))
+	assert.True(t, h.Enabled(ErrorLevel))
+}
+
+// Test that the tracing format is regenerated on every StartSpan and injected by CreateLogBuilder
+func TestObservabilityContext_SetTracingFormat(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{})
+	format := func(name string, tv TraceValues) string { return name + "=" + tv.SpanId }
+
+	// Assert the tracing format isn't injected before a span starts
+	assert.NoError(t, h.SetTracingFormat(format))
+	assert.Empty(t, h.CreateLogBuilder().CreateLogValuesBuilder().Build().Values(InfoLevel))
+
+	_, end := h.StartSpan("first")
+	first, _ := h.GetTraceValues()
+	assert.Equal(t, []string{"tracing=" + first.SpanId}, h.CreateLogBuilder().CreateLogValuesBuilder().Build().Values(InfoLevel))
+	end()
+
+	_, end = h.StartSpan("second")
+	defer end()
+	second, _ := h.GetTraceValues()
+	lb := h.CreateLogBuilder()
+	assert.Equal(t, []string{"tracing=" + second.SpanId}, lb.CreateLogValuesBuilder().Build().Values(DebugLevel))
+	assert.Equal(t, []string{"tracing=" + second.SpanId, "foo"}, lb.FactoryLogValuesBuilder(LogValuesOptions[string]{
+		NewLogValueOption[string]("foo").WithError(),
+	}).Build().Values(ErrorLevel))
+	assert.Equal(t, []string{"tracing=" + second.SpanId}, lb.AcquireLogValuesBuilder().Build().Values(InfoLevel))
+
+	// Assert the tracing format can be replaced
+	assert.NoError(t, h.SetTracingFormat(func(name string, tv TraceValues) string { return tv.TraceId }))
+	assert.Equal(t, []string{second.TraceId}, lb.CreateLogValuesBuilder().Build().Values(InfoLevel))
+	assert.Error(t, h.SetTracingFormat(nil))
+}
+
+// Test that GetTraceValues returns the parent, flags and state of the current span
+func TestObservabilityContext_GetTraceValues(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{})
+
+	_, err := h.GetTraceValues()
+	assert.Error(t, err)
+
+	// Assert a root span has no parent
+	_, end := h.StartSpan("root")
+	root, err := h.GetTraceValues()
+	assert.NoError(t, err)
+	assert.Equal(t, TraceValues{
+		TraceId:    tp.all()[0].sc.TraceID().String(),
+		SpanId:     tp.all()[0].sc.SpanID().String(),
+		Sampled:    true,
+		TraceFlags: "01",
+	}, root)
+
+	// Assert a child span keeps its local parent
+	_, endChild := h.StartSpan("child")
+	child, _ := h.GetTraceValues()
+	assert.Equal(t, root.TraceId, child.TraceId)
+	assert.Equal(t, root.SpanId, child.ParentSpanId)
+	assert.False(t, child.Remote)
+	endChild()
+	end()
+
+	// Assert a span continuing a propagated trace has a remote parent
+	state, _ := trace.ParseTraceState("vendor=value")
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x0a},
+		SpanID:     trace.SpanID{0x0b},
+		TraceState: state,
+		Remote:     true,
+	})
+	h = NewObservabilityHandler[string](trace.ContextWithRemoteSpanContext(context.Background(), remote), "checkout",
+		&recordingLogger{}, WithTracerProvider[string](tp))
+	_, end = h.StartSpan("server")
+	defer end()
+	server, _ := h.GetTraceValues()
+	assert.Equal(t, remote.TraceID().String(), server.TraceId)
+	assert.Equal(t, remote.SpanID().String(), server.ParentSpanId)
+	assert.True(t, server.Remote)
+}
+
+// Test that TraceValuesFromSpanContext keeps the trace state and ignores parents of other traces
+func TestTraceValuesFromSpanContext(t *testing.T) {
+	state, _ := trace.ParseTraceState("vendor=value")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceState: state,
+	})
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x03},
+		SpanID:  trace.SpanID{0x04},
+	})
+
+	tv := TraceValuesFromSpanContext(sc, parent)
+	assert.Equal(t, "vendor=value", tv.TraceState)
+	assert.Equal(t, "00", tv.TraceFlags)
+	assert.False(t, tv.Sampled)
+	assert.Empty(t, tv.ParentSpanId)
+}
+
+// Test that SetAttributes sets the attributes on the current span only once it's started
+func TestObservabilityContext_SetAttributes(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	h := NewObservabilityHandler[string](ctx, "checkout", &recordingLogger{})
+	h.SetAttributes(HTTPRoute("/ignored"))
+
+	_, end := h.StartSpan("GET /orders")
+	defer end()
+	h.SetAttributes(HTTPRequestMethod("GET"), HTTPRoute("/orders"))
+
+	assert.Equal(t, []attribute.KeyValue{HTTPRequestMethod("GET"), HTTPRoute("/orders")}, tp.all()[0].attrs)
+}
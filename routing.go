@@ -0,0 +1,85 @@
+package observability
+
+// Route decides whether a log is sent to the logger, added to the span or both
+type Route uint8
+
+const (
+	// RouteLogger sends the log to the logger
+	RouteLogger Route = 1 << iota
+	// RouteSpan adds the log to the span as an event, errors are recorded on the span
+	RouteSpan
+	// RouteBoth sends the log to the logger and adds it to the span
+	RouteBoth = RouteLogger | RouteSpan
+)
+
+// String returns the name of the route
+func (r Route) String() string {
+	switch r {
+	case RouteLogger:
+		return "logger"
+	case RouteSpan:
+		return "span"
+	case RouteBoth:
+		return "both"
+	default:
+		return "default"
+	}
+}
+
+// RoutingPolicy decides the route of the logs of each level
+// The route set on the log values with LogValuesBuilder.WithRoute takes precedence over the policy
+//
+// The policy must be configured before being given to a handler
+type RoutingPolicy struct {
+	// route is the route of the levels without route of their own
+	route Route
+	// levels are the routes of specific levels
+	levels map[Level]Route
+	// spanEventLevel is the minimum level added to the span, when spanEventLevelSet is set
+	spanEventLevel    Level
+	spanEventLevelSet bool
+}
+
+// NewRoutingPolicy creates a policy sending every level to both the logger and the span
+func NewRoutingPolicy() *RoutingPolicy {
+	return &RoutingPolicy{route: RouteBoth}
+}
+
+// WithDefaultRoute sets the route of the levels without route of their own
+func (p *RoutingPolicy) WithDefaultRoute(route Route) *RoutingPolicy {
+	p.route = route
+	return p
+}
+
+// WithRoute sets the route of the given level
+func (p *RoutingPolicy) WithRoute(level Level, route Route) *RoutingPolicy {
+	if p.levels == nil {
+		p.levels = make(map[Level]Route)
+	}
+	p.levels[level] = route
+	return p
+}
+
+// WithSpanEventThreshold only adds the logs of the given level and above to the span
+// Logs below the threshold routed to the span only are dropped
+func (p *RoutingPolicy) WithSpanEventThreshold(level Level) *RoutingPolicy {
+	p.spanEventLevel, p.spanEventLevelSet = level, true
+	return p
+}
+
+// Route returns the route of the given level
+// Every level is sent to both the logger and the span when the policy is nil
+func (p *RoutingPolicy) Route(level Level) Route {
+	if p == nil {
+		return RouteBoth
+	}
+
+	route, ok := p.levels[level]
+	if !ok {
+		route = p.route
+	}
+	if p.spanEventLevelSet && level < p.spanEventLevel {
+		route &^= RouteSpan
+	}
+	return route
+}
@@ -0,0 +1,63 @@
+package observability
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that the policy applies the level routes and the span event threshold
+func TestRoutingPolicy_Route(t *testing.T) {
+	var nilPolicy *RoutingPolicy
+	assert.Equal(t, RouteBoth, nilPolicy.Route(DebugLevel))
+
+	policy := NewRoutingPolicy().
+		WithRoute(ErrorLevel, RouteSpan).
+		WithSpanEventThreshold(InfoLevel)
+	assert.Equal(t, RouteLogger, policy.Route(DebugLevel))
+	assert.Equal(t, RouteBoth, policy.Route(InfoLevel))
+	assert.Equal(t, RouteSpan, policy.Route(ErrorLevel))
+
+	// Assert levels routed to the span only are dropped below the threshold
+	policy.WithRoute(DebugLevel, RouteSpan)
+	assert.Equal(t, Route(0), policy.Route(DebugLevel))
+
+	policy = NewRoutingPolicy().WithDefaultRoute(RouteLogger)
+	assert.Equal(t, RouteLogger, policy.Route(ErrorLevel))
+	assert.Equal(t, "logger", RouteLogger.String())
+}
+
+// Test that the handler sends the logs to the logger and the span following the route
+func TestObservabilityContext_Routing(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	policy := NewRoutingPolicy().WithSpanEventThreshold(InfoLevel).WithRoute(InfoLevel, RouteSpan)
+	h := NewObservabilityHandler[string](ctx, "checkout", logger, WithRoutingPolicy[string](policy))
+	_, end := h.StartSpan("GET /orders")
+	defer end()
+
+	h.LogDebug(msg("debug"))
+	h.LogInfo(msg("info"))
+	h.LogError(NewLogValuesBuilder[string]().WithMsg("error").WithErr(errors.New("boom")).Build())
+	// Assert the route of the log values overrides the policy
+	h.LogDebugContext(NewLogValuesBuilder[string]().WithMsg("span only").WithRoute(RouteSpan).Build())
+
+	assert.Equal(t, []string{"debug", "error"}, logger.messages())
+	var events []string
+	for _, event := range tp.all()[0].recordedEvents() {
+		events = append(events, event.name)
+	}
+	assert.Equal(t, []string{"info", "exception", "span only"}, events)
+}
+
+// Test that the route is kept by derived log values and cleared on reset
+func TestLogValues_Route(t *testing.T) {
+	b := NewLogValuesBuilder[string]().WithRoute(RouteLogger)
+	lv := b.Build()
+	assert.Equal(t, RouteLogger, lv.Route())
+	assert.Equal(t, RouteLogger, lv.Builder().Build().Route())
+
+	b.Reset()
+	assert.Equal(t, Route(0), b.Build().Route())
+}
@@ -0,0 +1,241 @@
+package observability
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Keys of the OpenTelemetry semantic conventions used by the helpers below
+// Adapters convert the attributes into their own type so the same key lands in logs and spans,
+// e.g. the zap adapter AttributeField
+const (
+	HTTPRequestMethodKey      = attribute.Key("http.request.method")
+	HTTPResponseStatusCodeKey = attribute.Key("http.response.status_code")
+	HTTPRouteKey              = attribute.Key("http.route")
+	URLFullKey                = attribute.Key("url.full")
+	URLPathKey                = attribute.Key("url.path")
+	URLSchemeKey              = attribute.Key("url.scheme")
+	ServerAddressKey          = attribute.Key("server.address")
+	ServerPortKey             = attribute.Key("server.port")
+	ClientAddressKey          = attribute.Key("client.address")
+	UserAgentOriginalKey      = attribute.Key("user_agent.original")
+
+	DBSystemKey    = attribute.Key("db.system")
+	DBNameKey      = attribute.Key("db.name")
+	DBStatementKey = attribute.Key("db.statement")
+	DBOperationKey = attribute.Key("db.operation")
+	DBSQLTableKey  = attribute.Key("db.sql.table")
+	DBUserKey      = attribute.Key("db.user")
+
+	MessagingSystemKey            = attribute.Key("messaging.system")
+	MessagingDestinationNameKey   = attribute.Key("messaging.destination.name")
+	MessagingOperationKey         = attribute.Key("messaging.operation")
+	MessagingMessageIDKey         = attribute.Key("messaging.message.id")
+	MessagingConversationIDKey    = attribute.Key("messaging.message.conversation_id")
+	MessagingMessageBodySizeKey   = attribute.Key("messaging.message.body.size")
+	MessagingBatchMessageCountKey = attribute.Key("messaging.batch.message_count")
+
+	RPCSystemKey         = attribute.Key("rpc.system")
+	RPCServiceKey        = attribute.Key("rpc.service")
+	RPCMethodKey         = attribute.Key("rpc.method")
+	RPCGRPCStatusCodeKey = attribute.Key("rpc.grpc.status_code")
+
+	ExceptionTypeKey       = attribute.Key("exception.type")
+	ExceptionMessageKey    = attribute.Key("exception.message")
+	ExceptionStacktraceKey = attribute.Key("exception.stacktrace")
+
+	EnduserIDKey         = attribute.Key("enduser.id")
+	EnduserRoleKey       = attribute.Key("enduser.role")
+	EnduserScopeKey      = attribute.Key("enduser.scope")
+	SessionIDKey         = attribute.Key("session.id")
+	SessionPreviousIDKey = attribute.Key("session.previous_id")
+)
+
+// HTTPRequestMethod returns the http.request.method attribute, e.g. GET
+func HTTPRequestMethod(method string) attribute.KeyValue {
+	return HTTPRequestMethodKey.String(method)
+}
+
+// HTTPResponseStatusCode returns the http.response.status_code attribute
+func HTTPResponseStatusCode(code int) attribute.KeyValue {
+	return HTTPResponseStatusCodeKey.Int(code)
+}
+
+// HTTPRoute returns the http.route attribute, e.g. /orders/{id}
+func HTTPRoute(route string) attribute.KeyValue {
+	return HTTPRouteKey.String(route)
+}
+
+// URLFull returns the url.full attribute
+func URLFull(url string) attribute.KeyValue {
+	return URLFullKey.String(url)
+}
+
+// URLPath returns the url.path attribute
+func URLPath(path string) attribute.KeyValue {
+	return URLPathKey.String(path)
+}
+
+// URLScheme returns the url.scheme attribute, e.g. https
+func URLScheme(scheme string) attribute.KeyValue {
+	return URLSchemeKey.String(scheme)
+}
+
+// ServerAddress returns the server.address attribute
+func ServerAddress(address string) attribute.KeyValue {
+	return ServerAddressKey.String(address)
+}
+
+// ServerPort returns the server.port attribute
+func ServerPort(port int) attribute.KeyValue {
+	return ServerPortKey.Int(port)
+}
+
+// ClientAddress returns the client.address attribute
+func ClientAddress(address string) attribute.KeyValue {
+	return ClientAddressKey.String(address)
+}
+
+// UserAgentOriginal returns the user_agent.original attribute
+func UserAgentOriginal(userAgent string) attribute.KeyValue {
+	return UserAgentOriginalKey.String(userAgent)
+}
+
+// DBSystem returns the db.system attribute, e.g. postgresql
+func DBSystem(system string) attribute.KeyValue {
+	return DBSystemKey.String(system)
+}
+
+// DBName returns the db.name attribute
+func DBName(name string) attribute.KeyValue {
+	return DBNameKey.String(name)
+}
+
+// DBStatement returns the db.statement attribute
+// Statements must be redacted before, they can contain sensitive values
+func DBStatement(statement string) attribute.KeyValue {
+	return DBStatementKey.String(statement)
+}
+
+// DBOperation returns the db.operation attribute, e.g. SELECT
+func DBOperation(operation string) attribute.KeyValue {
+	return DBOperationKey.String(operation)
+}
+
+// DBSQLTable returns the db.sql.table attribute
+func DBSQLTable(table string) attribute.KeyValue {
+	return DBSQLTableKey.String(table)
+}
+
+// DBUser returns the db.user attribute
+func DBUser(user string) attribute.KeyValue {
+	return DBUserKey.String(user)
+}
+
+// MessagingSystem returns the messaging.system attribute, e.g. kafka
+func MessagingSystem(system string) attribute.KeyValue {
+	return MessagingSystemKey.String(system)
+}
+
+// MessagingDestinationName returns the messaging.destination.name attribute, e.g. the topic or queue
+func MessagingDestinationName(name string) attribute.KeyValue {
+	return MessagingDestinationNameKey.String(name)
+}
+
+// MessagingOperation returns the messaging.operation attribute, e.g. publish, receive or process
+func MessagingOperation(operation string) attribute.KeyValue {
+	return MessagingOperationKey.String(operation)
+}
+
+// MessagingMessageID returns the messaging.message.id attribute
+func MessagingMessageID(id string) attribute.KeyValue {
+	return MessagingMessageIDKey.String(id)
+}
+
+// MessagingConversationID returns the messaging.message.conversation_id attribute
+func MessagingConversationID(id string) attribute.KeyValue {
+	return MessagingConversationIDKey.String(id)
+}
+
+// MessagingMessageBodySize returns the messaging.message.body.size attribute in bytes
+func MessagingMessageBodySize(size int) attribute.KeyValue {
+	return MessagingMessageBodySizeKey.Int(size)
+}
+
+// MessagingBatchMessageCount returns the messaging.batch.message_count attribute
+func MessagingBatchMessageCount(count int) attribute.KeyValue {
+	return MessagingBatchMessageCountKey.Int(count)
+}
+
+// RPCSystem returns the rpc.system attribute, e.g. grpc
+func RPCSystem(system string) attribute.KeyValue {
+	return RPCSystemKey.String(system)
+}
+
+// RPCService returns the rpc.service attribute
+func RPCService(service string) attribute.KeyValue {
+	return RPCServiceKey.String(service)
+}
+
+// RPCMethod returns the rpc.method attribute
+func RPCMethod(method string) attribute.KeyValue {
+	return RPCMethodKey.String(method)
+}
+
+// RPCGRPCStatusCode returns the rpc.grpc.status_code attribute
+func RPCGRPCStatusCode(code int) attribute.KeyValue {
+	return RPCGRPCStatusCodeKey.Int(code)
+}
+
+// ExceptionType returns the exception.type attribute
+func ExceptionType(typ string) attribute.KeyValue {
+	return ExceptionTypeKey.String(typ)
+}
+
+// ExceptionMessage returns the exception.message attribute
+func ExceptionMessage(message string) attribute.KeyValue {
+	return ExceptionMessageKey.String(message)
+}
+
+// ExceptionStacktrace returns the exception.stacktrace attribute
+func ExceptionStacktrace(stacktrace string) attribute.KeyValue {
+	return ExceptionStacktraceKey.String(stacktrace)
+}
+
+// Exception returns the exception.type and exception.message attributes of the given error
+// Returns nil when err is nil
+func Exception(err error) []attribute.KeyValue {
+	if err == nil {
+		return nil
+	}
+	return []attribute.KeyValue{
+		ExceptionType(fmt.Sprintf("%T", err)),
+		ExceptionMessage(err.Error()),
+	}
+}
+
+// EnduserID returns the enduser.id attribute
+func EnduserID(id string) attribute.KeyValue {
+	return EnduserIDKey.String(id)
+}
+
+// EnduserRole returns the enduser.role attribute
+func EnduserRole(role string) attribute.KeyValue {
+	return EnduserRoleKey.String(role)
+}
+
+// EnduserScope returns the enduser.scope attribute
+func EnduserScope(scope string) attribute.KeyValue {
+	return EnduserScopeKey.String(scope)
+}
+
+// SessionID returns the session.id attribute
+func SessionID(id string) attribute.KeyValue {
+	return SessionIDKey.String(id)
+}
+
+// SessionPreviousID returns the session.previous_id attribute
+func SessionPreviousID(id string) attribute.KeyValue {
+	return SessionPreviousIDKey.String(id)
+}
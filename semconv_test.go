@@ -0,0 +1,39 @@
+package observability
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Test that the helpers use the semantic convention keys and value types
+func TestSemconv(t *testing.T) {
+	tests := map[attribute.KeyValue]attribute.KeyValue{
+		HTTPRequestMethod("GET"):            attribute.String("http.request.method", "GET"),
+		HTTPResponseStatusCode(404):         attribute.Int("http.response.status_code", 404),
+		DBSystem("postgresql"):              attribute.String("db.system", "postgresql"),
+		MessagingDestinationName("orders"):  attribute.String("messaging.destination.name", "orders"),
+		MessagingBatchMessageCount(3):       attribute.Int("messaging.batch.message_count", 3),
+		RPCGRPCStatusCode(5):                attribute.Int("rpc.grpc.status_code", 5),
+		EnduserID("42"):                     attribute.String("enduser.id", "42"),
+		SessionID("abc"):                    attribute.String("session.id", "abc"),
+		ServerPort(8080):                    attribute.Int("server.port", 8080),
+		UserAgentOriginal("curl/8.0"):       attribute.String("user_agent.original", "curl/8.0"),
+		MessagingConversationID("thread-1"): attribute.String("messaging.message.conversation_id", "thread-1"),
+	}
+
+	for got, want := range tests {
+		assert.Equal(t, want, got)
+	}
+}
+
+// Test that Exception renders the type and message of the error
+func TestException(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("exception.type", "*errors.errorString"),
+		attribute.String("exception.message", "boom"),
+	}, Exception(errors.New("boom")))
+	assert.Nil(t, Exception(nil))
+}
@@ -0,0 +1,59 @@
+package observability
+
+// badKey is the key of the values without a string key in the sugared calls
+const badKey = "!BADKEY"
+
+// Debugw logs a debug message with loose key-value pairs and the observability context
+// Refer to Infow for the handling of the pairs
+func (oc *ObservabilityContext[T]) Debugw(msg string, keysAndValues ...any) {
+	oc.logw(DebugLevel, msg, keysAndValues)
+}
+
+// Infow logs an info message with loose key-value pairs and the observability context
+//
+// The pairs are converted with the ValueConverter of the handler, values of the log value type, e.g. zap fields,
+// are added as is unless they're strings, and values without a string key are logged under !BADKEY.
+// The first error is also set as the error of the log values, so Errorw records it on the span like LogErrorContext.
+// Only the message is logged without converter.
+func (oc *ObservabilityContext[T]) Infow(msg string, keysAndValues ...any) {
+	oc.logw(InfoLevel, msg, keysAndValues)
+}
+
+// Errorw logs an error message with loose key-value pairs and the observability context
+// Refer to Infow for the handling of the pairs
+func (oc *ObservabilityContext[T]) Errorw(msg string, keysAndValues ...any) {
+	oc.logw(ErrorLevel, msg, keysAndValues)
+}
+
+// logw builds the log values of a sugared call and logs them with the context
+func (oc *ObservabilityContext[T]) logw(level Level, msg string, keysAndValues []any) {
+	if !oc.enabled(level) && oc.recorder == nil {
+		return
+	}
+
+	b := oc.CreateLogBuilder().CreateLogValuesBuilder().WithMsg(msg)
+	var err error
+	for i := 0; i < len(keysAndValues); i++ {
+		// strings are always keys, even when the log value type is string
+		if _, isKey := keysAndValues[i].(string); !isKey {
+			if value, ok := keysAndValues[i].(T); ok {
+				b.WithAllLevelsValue(value)
+				continue
+			}
+		}
+
+		key, value := badKey, keysAndValues[i]
+		if k, ok := value.(string); ok && i+1 < len(keysAndValues) {
+			key, value = k, keysAndValues[i+1]
+			i++
+		}
+		if e, ok := value.(error); ok && err == nil {
+			err = e
+			b.WithErr(e)
+		}
+		if oc.convert != nil {
+			b.WithAllLevelsValue(oc.convert(key, value))
+		}
+	}
+	oc.log(level, true, b.Build(), nil)
+}
@@ -0,0 +1,79 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sprintConverter(key string, value any) string {
+	return fmt.Sprintf("%s=%v", key, value)
+}
+
+// Test that the sugared calls convert the pairs and log with the context
+func TestObservabilityContext_Sugared(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	h := NewObservabilityHandler[string](ctx, "checkout", logger, WithValueConverter[string](sprintConverter))
+	spanCtx, end := h.StartSpan("POST /orders")
+	defer end()
+
+	h.Debugw("loading cart", "items", 3)
+	h.Infow("charging card", "amount", 9.99, "currency", "EUR")
+	h.Errorw("card declined", "attempt", 2, "dangling")
+
+	entries := logger.all()
+	assert.Equal(t, []string{"loading cart", "charging card", "card declined"}, logger.messages())
+	assert.Equal(t, []Level{DebugLevel, InfoLevel, ErrorLevel}, []Level{entries[0].level, entries[1].level, entries[2].level})
+	assert.Equal(t, []string{"items=3"}, entries[0].lv.Values(DebugLevel))
+	assert.Equal(t, []string{"amount=9.99", "currency=EUR"}, entries[1].lv.Values(InfoLevel))
+	// Assert a key without value is logged under !BADKEY
+	assert.Equal(t, []string{"attempt=2", "!BADKEY=dangling"}, entries[2].lv.Values(ErrorLevel))
+	for _, entry := range entries {
+		assert.Equal(t, spanCtx, entry.ctx)
+	}
+}
+
+// Test that the first error of the pairs is recorded on the span by Errorw only
+func TestObservabilityContext_SugaredError(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	h := NewObservabilityHandler[string](ctx, "checkout", logger, WithValueConverter[string](sprintConverter))
+	_, end := h.StartSpan("POST /orders")
+
+	declined := errors.New("declined")
+	h.Errorw("card declined", "error", declined, 42)
+	end()
+
+	assert.Equal(t, []string{"error=declined", "!BADKEY=42"}, logger.all()[0].lv.Values(ErrorLevel))
+	events := tp.all()[0].recordedEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, declined, events[0].err)
+
+	// Assert Infow sets the error of the log values without recording it on the span
+	_, end = h.StartSpan("GET /orders")
+	h.Infow("card retried", "error", declined)
+	end()
+
+	assert.Equal(t, declined, logger.all()[1].lv.Err())
+	assert.Equal(t, []string{"error=declined"}, logger.all()[1].lv.Values(InfoLevel))
+	for _, event := range tp.all()[1].recordedEvents() {
+		assert.NoError(t, event.err)
+	}
+}
+
+// Test that only the message is logged without converter and nothing is built for disabled levels
+func TestObservabilityContext_SugaredWithoutConverter(t *testing.T) {
+	logger := &recordingLogger{level: DebugLevel}
+	h := NewObservabilityHandler[string](context.Background(), "checkout", logger,
+		WithLevelController[string](NewLevelController(InfoLevel)))
+
+	h.Debugw("hidden", "items", 3)
+	h.Infow("charging card", "amount", 9.99)
+
+	assert.Equal(t, []string{"charging card"}, logger.messages())
+	assert.Empty(t, logger.all()[0].lv.Values(InfoLevel))
+}
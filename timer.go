@@ -0,0 +1,87 @@
+package observability
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ValueConverter converts a key and a value into the value type of the adapter, e.g. zap.Any
+type ValueConverter[T any] func(key string, value any) T
+
+// Outcome is the result of the operation measured by a Timer
+type Outcome string
+
+const (
+	// OutcomeSuccess is the outcome of the operations that succeeded
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure is the outcome of the operations that failed
+	OutcomeFailure Outcome = "failure"
+)
+
+// OutcomeOf returns OutcomeFailure when err is set and OutcomeSuccess otherwise
+func OutcomeOf(err error) Outcome {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
+
+// Timer measures the duration of an operation, refer to ObservabilityContext.StartTimer
+type Timer[T any] struct {
+	handler *ObservabilityContext[T]
+	name    string
+	start   time.Time
+	level   Level
+	attrs   []attribute.KeyValue
+}
+
+// StartTimer starts a timer measuring the named operation
+// Stop logs the duration at the info level, adds it to the span and records it in the timer histogram, if set
+func (oc *ObservabilityContext[T]) StartTimer(name string, attrs ...attribute.KeyValue) *Timer[T] {
+	return &Timer[T]{handler: oc, name: name, start: time.Now(), level: InfoLevel, attrs: attrs}
+}
+
+// WithLevel sets the level the duration is logged at, e.g. DebugLevel for chatty operations
+func (t *Timer[T]) WithLevel(level Level) *Timer[T] {
+	t.level = level
+	return t
+}
+
+// Stop logs the duration and the outcome of the operation and returns the duration
+// The duration, the outcome and the attributes of the timer are logged with the ValueConverter of the handler
+// and recorded in the timer histogram in seconds
+func (t *Timer[T]) Stop(outcome Outcome) time.Duration {
+	duration := time.Since(t.start)
+	oc := t.handler
+
+	attrs := make([]attribute.KeyValue, 0, len(t.attrs)+3)
+	attrs = append(attrs,
+		attribute.String("timer.name", t.name),
+		attribute.String("timer.outcome", string(outcome)),
+	)
+	attrs = append(attrs, t.attrs...)
+
+	if oc.timerHistogram != nil {
+		oc.timerHistogram.Record(oc.ctx, duration.Seconds(), metric.WithAttributes(attrs...))
+	}
+
+	// the disabled levels are still logged to the flight recorder
+	if !oc.enabled(t.level) && oc.recorder == nil {
+		return duration
+	}
+	b := oc.CreateLogBuilder().CreateLogValuesBuilder().WithMsg(t.name)
+	if convert := oc.convert; convert != nil {
+		b.WithAllLevelsValue(convert("duration", duration)).
+			WithAllLevelsValue(convert("outcome", string(outcome)))
+		for _, kv := range t.attrs {
+			b.WithAllLevelsValue(convert(string(kv.Key), kv.Value.AsInterface()))
+		}
+	}
+	oc.log(t.level, true, b.Build(), []trace.EventOption{
+		trace.WithAttributes(append(attrs, attribute.Float64("timer.duration_seconds", duration.Seconds()))...),
+	})
+	return duration
+}
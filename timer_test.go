@@ -0,0 +1,81 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+// recordingHistogram is a Float64Histogram capturing every recorded value
+type recordingHistogram struct {
+	embedded.Float64Histogram
+	mu     sync.Mutex
+	values []float64
+	attrs  []attribute.Set
+}
+
+func (h *recordingHistogram) Record(_ context.Context, value float64, opts ...metric.RecordOption) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.values = append(h.values, value)
+	h.attrs = append(h.attrs, metric.NewRecordConfig(opts).Attributes())
+}
+
+// Test that Stop logs the duration, adds a span event and records the histogram
+func TestTimer_Stop(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	histogram := &recordingHistogram{}
+	convert := func(key string, value any) string { return fmt.Sprintf("%s=%v", key, value) }
+	h := NewObservabilityHandler[string](ctx, "checkout", logger,
+		WithValueConverter[string](convert),
+		WithTimerHistogram[string](histogram),
+	)
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	duration := h.StartTimer("charge card", attribute.String("provider", "stripe")).Stop(OutcomeOf(errors.New("declined")))
+
+	entries := logger.all()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, InfoLevel, entries[0].level)
+	assert.Equal(t, "charge card", entries[0].msg)
+	assert.Equal(t, []string{"duration=" + duration.String(), "outcome=failure", "provider=stripe"}, entries[0].lv.Values(InfoLevel))
+
+	events := tp.all()[0].recordedEvents()
+	assert.Len(t, events, 1)
+	assert.Contains(t, events[0].attrs, attribute.String("timer.outcome", "failure"))
+	assert.Contains(t, events[0].attrs, attribute.Float64("timer.duration_seconds", duration.Seconds()))
+
+	assert.Equal(t, []float64{duration.Seconds()}, histogram.values)
+	name, _ := histogram.attrs[0].Value("timer.name")
+	assert.Equal(t, "charge card", name.AsString())
+}
+
+// Test that disabled timers are only recorded in the histogram
+func TestTimer_WithLevel(t *testing.T) {
+	ctx, _ := newRecordingContext()
+	logger := &recordingLogger{level: DebugLevel}
+	histogram := &recordingHistogram{}
+	h := NewObservabilityHandler[string](ctx, "checkout", logger,
+		WithLevelController[string](NewLevelController(InfoLevel)),
+		WithTimerHistogram[string](histogram),
+	)
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	h.StartTimer("cache lookup").WithLevel(DebugLevel).Stop(OutcomeSuccess)
+	h.StartTimer("query").Stop(OutcomeOf(nil))
+
+	// Assert the timers only log their name without converter
+	assert.Equal(t, []string{"query"}, logger.messages())
+	assert.Empty(t, logger.all()[0].lv.Values(InfoLevel))
+	assert.Len(t, histogram.values, 2)
+}
@@ -0,0 +1,150 @@
+package observability
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingFormatter renders the trace values into the keys expected by a log vendor
+// Adapters convert the attributes into their own type, e.g. the zap adapter TracingFormat
+type TracingFormatter func(tv TraceValues) []attribute.KeyValue
+
+// TracingFormatterOptions contains the settings required by some formatters
+type TracingFormatterOptions struct {
+	// ProjectID is the Google Cloud project used by the gcp formatter
+	ProjectID string
+}
+
+// TracingFormatterNames are the names accepted by TracingFormatterByName
+var TracingFormatterNames = []string{"otel", "datadog", "gcp", "xray"}
+
+// TracingFormatterByName returns the formatter of the given vendor convention:
+// otel (trace_id, span_id), datadog (dd.trace_id, dd.span_id),
+// gcp (logging.googleapis.com/trace, logging.googleapis.com/spanId) or xray (AWS-XRAY-TRACE-ID)
+func TracingFormatterByName(name string, opts TracingFormatterOptions) (TracingFormatter, error) {
+	switch name {
+	case "otel":
+		return OTelTracingFormatter(), nil
+	case "datadog":
+		return DatadogTracingFormatter(), nil
+	case "gcp":
+		if opts.ProjectID == "" {
+			return nil, fmt.Errorf("tracing format %q requires a project id", name)
+		}
+		return GCPTracingFormatter(opts.ProjectID), nil
+	case "xray":
+		return XRayTracingFormatter(), nil
+	default:
+		return nil, fmt.Errorf("unknown tracing format %q", name)
+	}
+}
+
+// OTelTracingFormatter renders the ids as trace_id and span_id following the OpenTelemetry log data model
+// trace_flags and parent_span_id are added when known so span trees can be rebuilt from the logs
+func OTelTracingFormatter() TracingFormatter {
+	return func(tv TraceValues) []attribute.KeyValue {
+		kvs := []attribute.KeyValue{
+			attribute.String("trace_id", tv.TraceId),
+			attribute.String("span_id", tv.SpanId),
+		}
+		if tv.TraceFlags != "" {
+			kvs = append(kvs, attribute.String("trace_flags", tv.TraceFlags))
+		}
+		if tv.ParentSpanId != "" {
+			kvs = append(kvs, attribute.String("parent_span_id", tv.ParentSpanId))
+		}
+		return kvs
+	}
+}
+
+// DatadogTracingFormatter renders the ids as dd.trace_id and dd.span_id 64-bit decimal ids
+// Nothing is rendered when the trace values don't contain valid ids
+func DatadogTracingFormatter() TracingFormatter {
+	return func(tv TraceValues) []attribute.KeyValue {
+		traceID, err := DatadogTraceID(tv.TraceId)
+		if err != nil {
+			return nil
+		}
+		spanID, err := DatadogSpanID(tv.SpanId)
+		if err != nil {
+			return nil
+		}
+		return []attribute.KeyValue{
+			attribute.String("dd.trace_id", traceID),
+			attribute.String("dd.span_id", spanID),
+		}
+	}
+}
+
+// GCPTracingFormatter renders the ids and the sampled flag as the logging.googleapis.com special fields of the given project
+// Nothing is rendered when the trace values don't contain valid ids
+func GCPTracingFormatter(projectID string) TracingFormatter {
+	return func(tv TraceValues) []attribute.KeyValue {
+		if _, err := trace.TraceIDFromHex(tv.TraceId); err != nil {
+			return nil
+		}
+		return []attribute.KeyValue{
+			attribute.String("logging.googleapis.com/trace", GCPTrace(projectID, tv.TraceId)),
+			attribute.String("logging.googleapis.com/spanId", tv.SpanId),
+			attribute.Bool("logging.googleapis.com/trace_sampled", tv.Sampled),
+		}
+	}
+}
+
+// XRayTracingFormatter renders the ids as AWS-XRAY-TRACE-ID in the 1-xxxxxxxx-yyyy@spanId form
+// Nothing is rendered when the trace values don't contain valid ids
+func XRayTracingFormatter() TracingFormatter {
+	return func(tv TraceValues) []attribute.KeyValue {
+		traceID, err := XRayTraceID(tv.TraceId)
+		if err != nil {
+			return nil
+		}
+		return []attribute.KeyValue{
+			attribute.String("AWS-XRAY-TRACE-ID", traceID+"@"+tv.SpanId),
+		}
+	}
+}
+
+// DatadogTraceID converts a W3C trace id into the decimal of its lower 64 bits used by Datadog
+func DatadogTraceID(traceId string) (string, error) {
+	id, err := trace.TraceIDFromHex(traceId)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(beUint64(id[8:]), 10), nil
+}
+
+// DatadogSpanID converts a W3C span id into the decimal used by Datadog
+func DatadogSpanID(spanId string) (string, error) {
+	id, err := trace.SpanIDFromHex(spanId)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(beUint64(id[:]), 10), nil
+}
+
+// GCPTrace returns the trace resource name used by Cloud Logging
+func GCPTrace(projectID, traceId string) string {
+	return "projects/" + projectID + "/traces/" + traceId
+}
+
+// XRayTraceID converts a W3C trace id into the 1-xxxxxxxx-yyyyyyyyyyyyyyyyyyyyyyyy form used by AWS X-Ray
+// The first 8 hex digits are the epoch of the trace when generated by an X-Ray compatible id generator
+func XRayTraceID(traceId string) (string, error) {
+	if _, err := trace.TraceIDFromHex(traceId); err != nil {
+		return "", err
+	}
+	return "1-" + traceId[:8] + "-" + traceId[8:], nil
+}
+
+// beUint64 decodes 8 big-endian bytes
+func beUint64(b []byte) uint64 {
+	var v uint64
+	for _, c := range b[:8] {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
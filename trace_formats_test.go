@@ -0,0 +1,88 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var formatTraceValues = TraceValues{
+	TraceId: "5759e988bd862e3fe1be46a994272793",
+	SpanId:  "53995c3f42cd8ad8",
+
+	ParentSpanId: "0f0e0d0c0b0a0908",
+	Sampled:      true,
+	TraceFlags:   "01",
+}
+
+// Test that the Datadog ids are the decimal of the lower 64 bits
+func TestDatadogIDs(t *testing.T) {
+	traceID, err := DatadogTraceID(formatTraceValues.TraceId)
+	assert.NoError(t, err)
+	assert.Equal(t, "16266516598257821587", traceID)
+
+	spanID, err := DatadogSpanID(formatTraceValues.SpanId)
+	assert.NoError(t, err)
+	assert.Equal(t, "6023947403358210776", spanID)
+
+	_, err = DatadogTraceID("invalid")
+	assert.Error(t, err)
+}
+
+// Test that XRayTraceID splits the trace id into the X-Ray form
+func TestXRayTraceID(t *testing.T) {
+	traceID, err := XRayTraceID(formatTraceValues.TraceId)
+	assert.NoError(t, err)
+	assert.Equal(t, "1-5759e988-bd862e3fe1be46a994272793", traceID)
+}
+
+// Test that each formatter renders its vendor keys
+func TestTracingFormatterByName(t *testing.T) {
+	tests := map[string][]attribute.KeyValue{
+		"otel": {
+			attribute.String("trace_id", formatTraceValues.TraceId),
+			attribute.String("span_id", formatTraceValues.SpanId),
+			attribute.String("trace_flags", "01"),
+			attribute.String("parent_span_id", "0f0e0d0c0b0a0908"),
+		},
+		"datadog": {
+			attribute.String("dd.trace_id", "16266516598257821587"),
+			attribute.String("dd.span_id", "6023947403358210776"),
+		},
+		"gcp": {
+			attribute.String("logging.googleapis.com/trace", "projects/my-project/traces/"+formatTraceValues.TraceId),
+			attribute.String("logging.googleapis.com/spanId", formatTraceValues.SpanId),
+			attribute.Bool("logging.googleapis.com/trace_sampled", true),
+		},
+		"xray": {
+			attribute.String("AWS-XRAY-TRACE-ID", "1-5759e988-bd862e3fe1be46a994272793@53995c3f42cd8ad8"),
+		},
+	}
+
+	for _, name := range TracingFormatterNames {
+		formatter, err := TracingFormatterByName(name, TracingFormatterOptions{ProjectID: "my-project"})
+		assert.NoError(t, err)
+		assert.Equal(t, tests[name], formatter(formatTraceValues), name)
+	}
+
+	_, err := TracingFormatterByName("gcp", TracingFormatterOptions{})
+	assert.Error(t, err)
+	_, err = TracingFormatterByName("newrelic", TracingFormatterOptions{})
+	assert.Error(t, err)
+}
+
+// Test that vendor formatters render nothing without valid ids
+func TestTracingFormatter_InvalidIDs(t *testing.T) {
+	assert.Empty(t, DatadogTracingFormatter()(TraceValues{}))
+	assert.Empty(t, GCPTracingFormatter("my-project")(TraceValues{}))
+	assert.Empty(t, XRayTracingFormatter()(TraceValues{}))
+}
+
+// Test that the otel formatter only renders the ids when the flags and parent are unknown
+func TestOTelTracingFormatter_IDsOnly(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("trace_id", formatTraceValues.TraceId),
+		attribute.String("span_id", formatTraceValues.SpanId),
+	}, OTelTracingFormatter()(TraceValues{TraceId: formatTraceValues.TraceId, SpanId: formatTraceValues.SpanId}))
+}
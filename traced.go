@@ -0,0 +1,70 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PanicError is the error recorded when a traced function panics
+type PanicError struct {
+	// Value is the value given to panic
+	Value any
+	// Stack is the stack trace of the goroutine when it panicked
+	Stack []byte
+}
+
+// Error returns the panic value
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Trace runs fn in a child span of the handler's span and returns its result
+// Errors are logged with LogErrorContext and set as the status of the span,
+// panics are recorded the same way before panicking again
+func Trace[T, R any](h ObservabilityHandler[T], name string, fn func(ctx context.Context) (R, error), opts ...trace.SpanStartOption) (R, error) {
+	return traced(h, h.Context(), name, fn, false, opts)
+}
+
+// TraceVoid runs fn in a child span of the handler's span and returns its error, refer to Trace
+func TraceVoid[T any](h ObservabilityHandler[T], name string, fn func(ctx context.Context) error, opts ...trace.SpanStartOption) error {
+	_, err := Trace(h, name, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, opts...)
+	return err
+}
+
+// traced runs fn in a child span of the span in ctx with a handler derived from h
+// Panics are returned as a PanicError when recoverPanics is set and panic again otherwise
+func traced[T, R any](h ObservabilityHandler[T], ctx context.Context, name string, fn func(ctx context.Context) (R, error), recoverPanics bool, opts []trace.SpanStartOption) (result R, err error) {
+	child := h.WithContext(ctx)
+	ctx, end := child.StartSpan(name, opts...)
+	defer end()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+			failed(ctx, child, name, err)
+			if !recoverPanics {
+				panic(r)
+			}
+		}
+	}()
+
+	result, err = fn(ctx)
+	if err != nil {
+		failed(ctx, child, name, err)
+	}
+	return result, err
+}
+
+// failed logs the error of the named operation and sets it as the status of the span in ctx
+func failed[T any](ctx context.Context, h ObservabilityHandler[T], name string, err error) {
+	trace.SpanFromContext(ctx).SetStatus(codes.Error, err.Error())
+	h.LogErrorContext(h.CreateLogBuilder().CreateLogValuesBuilder().
+		WithMsg(name).
+		WithErr(err).
+		Build())
+}
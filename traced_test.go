@@ -0,0 +1,83 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Test that Trace runs the function in a child span and returns its result
+func TestTrace(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{}
+	h := NewObservabilityHandler[string](ctx, "checkout", logger)
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+	parent, _ := h.GetTraceValues()
+
+	result, err := Trace(h, "load order", func(ctx context.Context) (int, error) {
+		// Assert the context carries the child span and its handler
+		_, ok := HandlerFromContext[string](ctx)
+		assert.True(t, ok)
+		return 42, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result)
+
+	child := tp.all()[1]
+	assert.Equal(t, "load order", child.name)
+	assert.Equal(t, parent.SpanId, child.parent.SpanID().String())
+	assert.True(t, child.ended)
+	assert.Empty(t, logger.all())
+}
+
+// Test that TraceVoid logs the error and sets the status of the span
+func TestTraceVoid_Error(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{}
+	h := NewObservabilityHandler[string](ctx, "checkout", logger)
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	err := TraceVoid(h, "charge card", func(context.Context) error { return errors.New("declined") },
+		trace.WithSpanKind(trace.SpanKindClient))
+	assert.EqualError(t, err, "declined")
+
+	child := tp.all()[1]
+	assert.Equal(t, codes.Error, child.status)
+	assert.Equal(t, trace.SpanKindClient, child.config.SpanKind())
+	assert.Equal(t, "exception", child.recordedEvents()[0].name)
+
+	entries := logger.all()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, ErrorLevel, entries[0].level)
+	assert.Equal(t, "charge card", entries[0].msg)
+	// Assert the parent span isn't affected
+	assert.Empty(t, tp.all()[0].recordedEvents())
+}
+
+// Test that Trace records the panics before panicking again
+func TestTrace_Panic(t *testing.T) {
+	ctx, tp := newRecordingContext()
+	logger := &recordingLogger{}
+	h := NewObservabilityHandler[string](ctx, "checkout", logger)
+	_, end := h.StartSpan("POST /orders")
+	defer end()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = TraceVoid(h, "explode", func(context.Context) error { panic("boom") })
+	})
+
+	child := tp.all()[1]
+	assert.True(t, child.ended)
+	assert.Equal(t, codes.Error, child.status)
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, logger.all()[0].lv.Err(), &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+}
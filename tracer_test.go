@@ -0,0 +1,136 @@
+package observability
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanEvent is an event captured by recordingSpan
+type spanEvent struct {
+	name  string
+	err   error
+	attrs []attribute.KeyValue
+}
+
+// recordingTracerProvider is a TracerProvider capturing every started span
+type recordingTracerProvider struct {
+	mu    sync.Mutex
+	ids   byte
+	spans []*recordingSpan
+}
+
+// newRecordingContext returns a context whose span starts its children in the returned provider
+func newRecordingContext() (context.Context, *recordingTracerProvider) {
+	tp := &recordingTracerProvider{}
+	return trace.ContextWithSpan(context.Background(), &recordingSpan{tp: tp}), tp
+}
+
+func (tp *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return recordingTracer{tp: tp}
+}
+
+func (tp *recordingTracerProvider) all() []*recordingSpan {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	return append([]*recordingSpan(nil), tp.spans...)
+}
+
+type recordingTracer struct {
+	tp *recordingTracerProvider
+}
+
+func (t recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.tp.mu.Lock()
+	defer t.tp.mu.Unlock()
+
+	parent := trace.SpanContextFromContext(ctx)
+	t.tp.ids++
+	traceID := parent.TraceID()
+	if !traceID.IsValid() {
+		traceID = trace.TraceID{0x01, t.tp.ids}
+	}
+	span := &recordingSpan{
+		tp:     t.tp,
+		name:   name,
+		config: trace.NewSpanStartConfig(opts...),
+		parent: parent,
+		sc: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{0x02, t.tp.ids},
+			TraceFlags: trace.FlagsSampled,
+		}),
+	}
+	t.tp.spans = append(t.tp.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// recordingSpan is a Span capturing its events, attributes and status
+type recordingSpan struct {
+	mu     sync.Mutex
+	tp     *recordingTracerProvider
+	name   string
+	config trace.SpanConfig
+	parent trace.SpanContext
+	sc     trace.SpanContext
+	events []spanEvent
+	attrs  []attribute.KeyValue
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordingSpan) End(...trace.SpanEndOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ended = true
+}
+
+func (s *recordingSpan) AddEvent(name string, opts ...trace.EventOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cfg := trace.NewEventConfig(opts...)
+	s.events = append(s.events, spanEvent{name: name, attrs: cfg.Attributes()})
+}
+
+func (s *recordingSpan) IsRecording() bool { return true }
+
+func (s *recordingSpan) RecordError(err error, opts ...trace.EventOption) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cfg := trace.NewEventConfig(opts...)
+	s.events = append(s.events, spanEvent{name: "exception", err: err, attrs: cfg.Attributes()})
+}
+
+func (s *recordingSpan) SpanContext() trace.SpanContext { return s.sc }
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = code
+}
+
+func (s *recordingSpan) SetName(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.name = name
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, kv...)
+}
+
+func (s *recordingSpan) TracerProvider() trace.TracerProvider { return s.tp }
+
+func (s *recordingSpan) recordedEvents() []spanEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]spanEvent(nil), s.events...)
+}